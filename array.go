@@ -2,9 +2,220 @@
 
 package gs
 
+import "fmt"
+
 var ArrayConstructor = Function{Global.Get("Array"), 0}
 
-// TODO: implement Array
+var _ Valuer = Array{}
+
+// Array is a JavaScript array.
+//
+// Its methods return an error if JavaScript throws, such as for a frozen
+// array, an element whose toString throws, or an array behind a Proxy.
 type Array struct {
 	Object
 }
+
+// ArrayOf converts a JavaScript value into an Array, if Array.isArray
+// reports that it is one.
+func ArrayOf(v Valuer) (Array, bool) {
+	vv := v.ValueOf()
+
+	if !isArray(vv) {
+		return Array{}, false
+	}
+
+	return Array{
+		Object: Object{Value: vv},
+	}, true
+}
+
+// maxArrayArgs bounds the arguments NewArray passes in one call, well below
+// the smallest engine limit (about 64K in JavaScriptCore).
+const maxArrayArgs = 1 << 14
+
+// NewArray returns a new array containing elems, created with Array.of and,
+// for long slices, further calls to push, each taking a chunk of elems.
+func NewArray(elems ...Valuer) (Array, error) {
+	first := elems
+	if len(first) > maxArrayArgs {
+		first = first[:maxArrayArgs]
+	}
+
+	v, err := Object{Value: ArrayConstructor.Value}.Call("of", first...)
+	if err != nil {
+		return Array{}, err
+	}
+
+	a := Array{Object: Object{Value: v}}
+
+	for rest := elems[len(first):]; len(rest) > 0; {
+		n := len(rest)
+		if n > maxArrayArgs {
+			n = maxArrayArgs
+		}
+
+		if _, err := a.Call("push", rest[:n]...); err != nil {
+			return Array{}, err
+		}
+
+		rest = rest[n:]
+	}
+
+	return a, nil
+}
+
+// ArrayFrom returns a new array containing the elements of the iterable or
+// array-like value v, like Array.from.
+func ArrayFrom(v Valuer) (Array, error) {
+	a, err := Object{Value: ArrayConstructor.Value}.Call("from", v)
+	if err != nil {
+		return Array{}, err
+	}
+
+	return Array{Object: Object{Value: a}}, nil
+}
+
+func (a Array) ValueOf() Value {
+	return a.Value
+}
+
+// callArray calls the method m of a, which returns an array.
+func (a Array) callArray(m string, args ...Valuer) (Array, error) {
+	res, err := a.Call(m, args...)
+	if err != nil {
+		return Array{}, err
+	}
+
+	return Array{Object: Object{Value: res}}, nil
+}
+
+// callInt calls the method m of a, which returns an integer.
+func (a Array) callInt(m string, args ...Valuer) (int, error) {
+	res, err := a.Call(m, args...)
+	if err != nil {
+		return 0, err
+	}
+
+	return res.Int(), nil
+}
+
+// Len returns the length of a.
+func (a Array) Len() int {
+	return a.Length()
+}
+
+// At returns the element of a at index i, counting back from the end if i is
+// negative. It returns undefined if i is out of range.
+func (a Array) At(i int) (Value, error) {
+	if i < 0 {
+		i += a.Len()
+	}
+
+	if i < 0 {
+		return Undefined.Value, nil
+	}
+
+	return Reflect.Get(a.Object, IndexKey(i))
+}
+
+// Push appends vs to a and returns the new length.
+func (a Array) Push(vs ...Valuer) (int, error) {
+	return a.callInt("push", vs...)
+}
+
+// Pop removes and returns the last element of a, or undefined if a is empty.
+func (a Array) Pop() (Value, error) {
+	return a.Call("pop")
+}
+
+// Shift removes and returns the first element of a, or undefined if a is
+// empty.
+func (a Array) Shift() (Value, error) {
+	return a.Call("shift")
+}
+
+// Unshift prepends vs to a and returns the new length.
+func (a Array) Unshift(vs ...Valuer) (int, error) {
+	return a.callInt("unshift", vs...)
+}
+
+// Slice returns a new array of the elements of a from start up to end,
+// counting back from the end for negative indices.
+func (a Array) Slice(start, end int) (Array, error) {
+	return a.callArray("slice", ValueOf(start), ValueOf(end))
+}
+
+// SliceFrom returns a new array of the elements of a from start to the end,
+// like a.slice(start), counting back from the end if start is negative.
+func (a Array) SliceFrom(start int) (Array, error) {
+	return a.callArray("slice", ValueOf(start))
+}
+
+// Splice removes deleteCount elements of a from start, inserts items in
+// their place, and returns the removed elements.
+func (a Array) Splice(start, deleteCount int, items ...Valuer) (Array, error) {
+	args := append([]Valuer{ValueOf(start), ValueOf(deleteCount)}, items...)
+	return a.callArray("splice", args...)
+}
+
+// Concat returns a new array of the elements of a followed by vs, with any
+// arrays in vs flattened one level.
+func (a Array) Concat(vs ...Valuer) (Array, error) {
+	return a.callArray("concat", vs...)
+}
+
+// IndexOf returns the first index of v in a using ===, or -1 if v is not
+// present.
+func (a Array) IndexOf(v Valuer) (int, error) {
+	return a.callInt("indexOf", v)
+}
+
+// Includes reports whether a contains v using SameValueZero, so unlike
+// IndexOf it finds NaN.
+func (a Array) Includes(v Valuer) (bool, error) {
+	res, err := a.Call("includes", v)
+	if err != nil {
+		return false, err
+	}
+
+	return res.Truthy(), nil
+}
+
+// Join returns the elements of a converted to strings and separated by sep.
+func (a Array) Join(sep string) (string, error) {
+	res, err := a.Call("join", ToString(sep))
+	if err != nil {
+		return "", err
+	}
+
+	return jsString(res), nil
+}
+
+// Reverse reverses a in place and returns it.
+func (a Array) Reverse() (Array, error) {
+	_, err := a.Call("reverse")
+	return a, err
+}
+
+// Sort sorts a in place and returns it. The Go function cmp reports whether
+// x sorts before (negative), after (positive) or equal to (zero) y; undefined
+// elements are always sorted last without calling cmp. If cmp is nil, the
+// elements are sorted by their string values.
+func (a Array) Sort(cmp func(x, y Value) int) (Array, error) {
+	if cmp == nil {
+		_, err := a.Call("sort")
+		return a, err
+	}
+
+	fn, err := WrapFunction(func(_ Value, args []Value) any {
+		return cmp(args[0], args[1])
+	})
+	if err != nil {
+		return a, fmt.Errorf("wrap comparator: %w", err)
+	}
+	defer fn.Release()
+
+	_, err = a.Call("sort", fn)
+	return a, err
+}
@@ -0,0 +1,375 @@
+//go:build wasm && js
+
+package gs
+
+import (
+	"errors"
+	"io"
+)
+
+var (
+	ArrayBufferConstructor       = Function{Value: Global.Get("ArrayBuffer")}
+	SharedArrayBufferConstructor = Function{Value: Global.Get("SharedArrayBuffer")}
+)
+
+var (
+	_ io.ReaderAt   = ArrayBuffer{}
+	_ io.WriterAt   = ArrayBuffer{}
+	_ io.ReaderFrom = ArrayBuffer{}
+
+	_ io.ReaderAt   = SharedArrayBuffer{}
+	_ io.WriterAt   = SharedArrayBuffer{}
+	_ io.ReaderFrom = SharedArrayBuffer{}
+)
+
+// ErrBufferOffset is returned when reading or writing a buffer at a negative
+// offset.
+var ErrBufferOffset = errors.New("negative buffer offset")
+
+// ArrayBuffer is a JavaScript ArrayBuffer.
+//
+// It implements io.ReaderAt, io.WriterAt and io.ReaderFrom by copying through
+// temporary Uint8Array views, so Go code can read and write its bytes without
+// first copying the whole buffer.
+type ArrayBuffer struct {
+	Object
+}
+
+// ArrayBufferOf converts a JavaScript value into an ArrayBuffer, if it is one.
+func ArrayBufferOf(v Valuer) (ArrayBuffer, bool) {
+	o, ok := ObjectOf(v)
+	if !ok || !o.InstanceOf(ArrayBufferConstructor.Value) {
+		return ArrayBuffer{}, false
+	}
+
+	return ArrayBuffer{Object: o}, true
+}
+
+// NewArrayBuffer returns a new ArrayBuffer of length bytes, filled with zeros.
+func NewArrayBuffer(length int) (ArrayBuffer, error) {
+	b, err := ArrayBufferConstructor.New(ValueOf(length))
+	if err != nil {
+		return ArrayBuffer{}, err
+	}
+
+	return ArrayBuffer{Object: Object{Value: b}}, nil
+}
+
+// NewResizableArrayBuffer returns a new ArrayBuffer of length bytes, which
+// can be resized up to maxLength bytes.
+func NewResizableArrayBuffer(length, maxLength int) (ArrayBuffer, error) {
+	b, err := ArrayBufferConstructor.New(ValueOf(length), maxLengthOptions(maxLength))
+	if err != nil {
+		return ArrayBuffer{}, err
+	}
+
+	return ArrayBuffer{Object: Object{Value: b}}, nil
+}
+
+func (b ArrayBuffer) ValueOf() Value {
+	return b.Value
+}
+
+// ByteLength returns the length of b in bytes. It is zero once b has been
+// transferred.
+func (b ArrayBuffer) ByteLength() int {
+	return b.Get("byteLength").Int()
+}
+
+// Resizable reports whether b can be resized.
+func (b ArrayBuffer) Resizable() bool {
+	return b.Get("resizable").Truthy()
+}
+
+// MaxByteLength returns the length in bytes that b can be resized to, which
+// is its ByteLength if it is not resizable.
+func (b ArrayBuffer) MaxByteLength() int {
+	return maxByteLength(b.Object)
+}
+
+// Resize changes the length of a resizable buffer to length bytes. New bytes
+// are zeroed.
+func (b ArrayBuffer) Resize(length int) error {
+	_, err := b.Call("resize", ValueOf(length))
+	return err
+}
+
+// Slice returns a new ArrayBuffer holding a copy of the bytes of b from begin
+// up to end. Negative indices count back from the end.
+func (b ArrayBuffer) Slice(begin, end int) ArrayBuffer {
+	return ArrayBuffer{Object: Object{Value: sliceBuffer(b.Object, begin, end)}}
+}
+
+// Transfer moves the contents of b into a new ArrayBuffer of length bytes,
+// leaving b detached. The new buffer is resizable if b is. It returns an
+// error where ArrayBuffer.prototype.transfer is not supported.
+func (b ArrayBuffer) Transfer(length int) (ArrayBuffer, error) {
+	t, err := b.Call("transfer", ValueOf(length))
+	if err != nil {
+		return ArrayBuffer{}, err
+	}
+
+	return ArrayBuffer{Object: Object{Value: t}}, nil
+}
+
+// ReadAt copies bytes of b from offset off into p, like io.ReaderAt.
+func (b ArrayBuffer) ReadAt(p []byte, off int64) (int, error) {
+	return readBufferAt(b.Value, 0, b.ByteLength(), p, off)
+}
+
+// WriteAt copies p into b at offset off, like io.WriterAt. A resizable buffer
+// is grown to fit p if it can be; otherwise the bytes that don't fit are
+// dropped and io.ErrShortWrite is returned.
+func (b ArrayBuffer) WriteAt(p []byte, off int64) (int, error) {
+	return writeBufferAt(b.Object, 0, b.ByteLength(), p, off, b.Resize)
+}
+
+// ReadFrom fills b from the start with data read from r until EOF, like
+// io.ReaderFrom. A resizable buffer is grown as needed; if r holds more data
+// than b can fit, io.ErrShortBuffer is returned.
+func (b ArrayBuffer) ReadFrom(r io.Reader) (int64, error) {
+	return readBufferFrom(b.Object, r, b.Resize)
+}
+
+// SharedArrayBuffer is a JavaScript SharedArrayBuffer, whose memory may be
+// shared between workers. It is only available where the page is
+// cross-origin isolated.
+//
+// It implements io.ReaderAt, io.WriterAt and io.ReaderFrom the same way as
+// ArrayBuffer. The copies are not atomic.
+type SharedArrayBuffer struct {
+	Object
+}
+
+// SharedArrayBufferOf converts a JavaScript value into a SharedArrayBuffer, if
+// it is one.
+func SharedArrayBufferOf(v Valuer) (SharedArrayBuffer, bool) {
+	o, ok := ObjectOf(v)
+	if !ok || SharedArrayBufferConstructor.IsUndefined() || !o.InstanceOf(SharedArrayBufferConstructor.Value) {
+		return SharedArrayBuffer{}, false
+	}
+
+	return SharedArrayBuffer{Object: o}, true
+}
+
+// NewSharedArrayBuffer returns a new SharedArrayBuffer of length bytes, filled
+// with zeros.
+func NewSharedArrayBuffer(length int) (SharedArrayBuffer, error) {
+	b, err := SharedArrayBufferConstructor.New(ValueOf(length))
+	if err != nil {
+		return SharedArrayBuffer{}, err
+	}
+
+	return SharedArrayBuffer{Object: Object{Value: b}}, nil
+}
+
+// NewGrowableSharedArrayBuffer returns a new SharedArrayBuffer of length
+// bytes, which can be grown up to maxLength bytes.
+func NewGrowableSharedArrayBuffer(length, maxLength int) (SharedArrayBuffer, error) {
+	b, err := SharedArrayBufferConstructor.New(ValueOf(length), maxLengthOptions(maxLength))
+	if err != nil {
+		return SharedArrayBuffer{}, err
+	}
+
+	return SharedArrayBuffer{Object: Object{Value: b}}, nil
+}
+
+func (b SharedArrayBuffer) ValueOf() Value {
+	return b.Value
+}
+
+// ByteLength returns the length of b in bytes.
+func (b SharedArrayBuffer) ByteLength() int {
+	return b.Get("byteLength").Int()
+}
+
+// Growable reports whether b can be grown.
+func (b SharedArrayBuffer) Growable() bool {
+	return b.Get("growable").Truthy()
+}
+
+// MaxByteLength returns the length in bytes that b can be grown to, which is
+// its ByteLength if it is not growable.
+func (b SharedArrayBuffer) MaxByteLength() int {
+	return maxByteLength(b.Object)
+}
+
+// Grow increases the length of a growable buffer to length bytes. Shared
+// buffers can't shrink, so length must be at least ByteLength.
+func (b SharedArrayBuffer) Grow(length int) error {
+	_, err := b.Call("grow", ValueOf(length))
+	return err
+}
+
+// Slice returns a new SharedArrayBuffer holding a copy of the bytes of b from
+// begin up to end. Negative indices count back from the end.
+func (b SharedArrayBuffer) Slice(begin, end int) SharedArrayBuffer {
+	return SharedArrayBuffer{Object: Object{Value: sliceBuffer(b.Object, begin, end)}}
+}
+
+// ReadAt copies bytes of b from offset off into p, like io.ReaderAt.
+func (b SharedArrayBuffer) ReadAt(p []byte, off int64) (int, error) {
+	return readBufferAt(b.Value, 0, b.ByteLength(), p, off)
+}
+
+// WriteAt copies p into b at offset off, like io.WriterAt. A growable buffer
+// is grown to fit p if it can be; otherwise the bytes that don't fit are
+// dropped and io.ErrShortWrite is returned.
+func (b SharedArrayBuffer) WriteAt(p []byte, off int64) (int, error) {
+	return writeBufferAt(b.Object, 0, b.ByteLength(), p, off, b.Grow)
+}
+
+// ReadFrom fills b from the start with data read from r until EOF, like
+// io.ReaderFrom. A growable buffer is grown as needed; if r holds more data
+// than b can fit, io.ErrShortBuffer is returned.
+func (b SharedArrayBuffer) ReadFrom(r io.Reader) (int64, error) {
+	return readBufferFrom(b.Object, r, b.Grow)
+}
+
+func maxLengthOptions(maxLength int) Value {
+	o, err := ObjectConstructor.New()
+	if err != nil {
+		panic("object construction error: " + err.Error())
+	}
+
+	o.Set("maxByteLength", maxLength)
+	return o
+}
+
+func maxByteLength(b Object) int {
+	m := b.Get("maxByteLength")
+	if m.IsUndefined() {
+		// engines without resizable buffers
+		return b.Get("byteLength").Int()
+	}
+
+	return m.Int()
+}
+
+func sliceBuffer(b Object, begin, end int) Value {
+	s, err := b.Call("slice", ValueOf(begin), ValueOf(end))
+	if err != nil {
+		panic("slice: " + err.Error())
+	}
+
+	return s
+}
+
+// byteView returns a Uint8Array viewing length bytes of buffer from offset.
+func byteView(buffer Value, offset, length int) Uint8Array {
+	v, err := Uint8ArrayConstructor.New(buffer, ValueOf(offset), ValueOf(length))
+	if err != nil {
+		panic("byte view: " + err.Error())
+	}
+
+	return Uint8Array{TypedArray: TypedArray{Object: Object{Value: v}}}
+}
+
+// readBufferAt copies into p the bytes from offset off of the size bytes of
+// buffer that start at base.
+func readBufferAt(buffer Value, base, length int, p []byte, off int64) (int, error) {
+	if off < 0 {
+		return 0, ErrBufferOffset
+	}
+
+	size := int64(length)
+	if off >= size {
+		return 0, io.EOF
+	}
+
+	n := len(p)
+	if int64(n) > size-off {
+		n = int(size - off)
+	}
+
+	if n > 0 {
+		byteView(buffer, base+int(off), n).CopyBytesToGo(p[:n])
+	}
+
+	if n < len(p) {
+		return n, io.EOF
+	}
+
+	return n, nil
+}
+
+// writeBufferAt copies p to offset off of the length bytes of buffer that
+// start at base. If grow is not nil, it is called to grow buffer to fit p.
+func writeBufferAt(buffer Object, base, length int, p []byte, off int64, grow func(int) error) (int, error) {
+	if off < 0 {
+		return 0, ErrBufferOffset
+	}
+
+	size := int64(length)
+	if end := off + int64(len(p)); grow != nil && end > size && end <= int64(maxByteLength(buffer)) {
+		if grow(int(end)) == nil {
+			size = end
+		}
+	}
+
+	if off >= size {
+		return 0, io.ErrShortWrite
+	}
+
+	n := len(p)
+	if int64(n) > size-off {
+		n = int(size - off)
+	}
+
+	if n > 0 {
+		byteView(buffer.Value, base+int(off), n).CopyBytesToJS(p[:n])
+	}
+
+	if n < len(p) {
+		return n, io.ErrShortWrite
+	}
+
+	return n, nil
+}
+
+// readBufferChunk is the most ReadFrom reads from its io.Reader at once.
+const readBufferChunk = 32 * 1024
+
+func readBufferFrom(b Object, r io.Reader, grow func(int) error) (int64, error) {
+	size := b.Get("byteLength").Int()
+	limit := maxByteLength(b)
+
+	buf := make([]byte, readBufferChunk)
+
+	var total int
+	for {
+		// read at least one byte when full, to tell whether r is done
+		n := len(buf)
+		if room := limit - total; room < n && room > 0 {
+			n = room
+		} else if room <= 0 {
+			n = 1
+		}
+
+		m, err := r.Read(buf[:n])
+		if m > 0 {
+			if total+m > limit {
+				return int64(total), io.ErrShortBuffer
+			}
+
+			if total+m > size {
+				if err := grow(total + m); err != nil {
+					return int64(total), err
+				}
+
+				size = total + m
+			}
+
+			byteView(b.Value, total, m).CopyBytesToJS(buf[:m])
+			total += m
+		}
+
+		if err == io.EOF {
+			return int64(total), nil
+		}
+
+		if err != nil {
+			return int64(total), err
+		}
+	}
+}
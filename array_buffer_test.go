@@ -0,0 +1,135 @@
+//go:build wasm && js
+
+package gs_test
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/superloach/gs"
+)
+
+func TestArrayBufferReadAt(t *testing.T) {
+	b, ok := gs.ArrayBufferOf(eval(t, "new Uint8Array([1, 0, 2, 0, 0, 0, 3]).buffer"))
+	if !ok {
+		t.Fatal("ArrayBufferOf rejected an ArrayBuffer")
+	}
+
+	var hdr struct {
+		A uint16
+		B uint32
+	}
+
+	r := io.NewSectionReader(b, 0, int64(b.ByteLength()))
+	if err := binary.Read(r, binary.LittleEndian, &hdr); err != nil {
+		t.Fatalf("binary.Read: %v", err)
+	}
+
+	if hdr.A != 1 || hdr.B != 2 {
+		t.Errorf("binary.Read: got %+v", hdr)
+	}
+
+	p := make([]byte, 4)
+	if n, err := b.ReadAt(p, 5); n != 2 || err != io.EOF || p[1] != 3 {
+		t.Errorf("ReadAt past end: got %d, %v", n, err)
+	}
+}
+
+func TestArrayBufferWriteAt(t *testing.T) {
+	b, err := gs.NewArrayBuffer(4)
+	if err != nil {
+		t.Fatalf("new: %v", err)
+	}
+
+	if n, err := b.WriteAt([]byte{7, 8, 9}, 2); n != 2 || err != io.ErrShortWrite {
+		t.Errorf("WriteAt past end: got %d, %v", n, err)
+	}
+
+	if got := b.Slice(1, 4); got.ByteLength() != 3 {
+		t.Errorf("Slice: got %d bytes", got.ByteLength())
+	}
+
+	p := make([]byte, 4)
+	b.ReadAt(p, 0)
+	if !bytes.Equal(p, []byte{0, 0, 7, 8}) {
+		t.Errorf("after WriteAt: got %v", p)
+	}
+
+	if err := b.Resize(8); err == nil {
+		t.Error("Resize of a fixed-length buffer succeeded")
+	}
+}
+
+func TestArrayBufferResizable(t *testing.T) {
+	b, err := gs.NewResizableArrayBuffer(2, 8)
+	if err != nil {
+		t.Fatalf("new: %v", err)
+	}
+
+	if !b.Resizable() || b.MaxByteLength() != 8 {
+		t.Errorf("Resizable/MaxByteLength: got %v/%d", b.Resizable(), b.MaxByteLength())
+	}
+
+	if n, err := b.ReadFrom(strings.NewReader("hello")); n != 5 || err != nil {
+		t.Errorf("ReadFrom: got %d, %v", n, err)
+	}
+
+	if b.ByteLength() != 5 {
+		t.Errorf("ReadFrom: grew to %d bytes", b.ByteLength())
+	}
+
+	if _, err := b.ReadFrom(strings.NewReader("too long to fit")); !errors.Is(err, io.ErrShortBuffer) {
+		t.Errorf("ReadFrom overflow: got %v", err)
+	}
+
+	if _, err := b.WriteAt([]byte("!"), 8); err != io.ErrShortWrite {
+		t.Errorf("WriteAt past max: got %v", err)
+	}
+}
+
+func TestArrayBufferTransfer(t *testing.T) {
+	b, err := gs.NewArrayBuffer(4)
+	if err != nil {
+		t.Fatalf("new: %v", err)
+	}
+
+	b.WriteAt([]byte{1, 2, 3, 4}, 0)
+
+	tb, err := b.Transfer(2)
+	if err != nil {
+		var me gs.MethodError
+		if errors.As(err, &me) {
+			t.Skip("ArrayBuffer.prototype.transfer not supported")
+		}
+
+		t.Fatalf("Transfer: %v", err)
+	}
+
+	if b.ByteLength() != 0 || tb.ByteLength() != 2 {
+		t.Errorf("Transfer: lengths %d and %d", b.ByteLength(), tb.ByteLength())
+	}
+}
+
+func TestSharedArrayBuffer(t *testing.T) {
+	b, err := gs.NewGrowableSharedArrayBuffer(0, 4)
+	if err != nil {
+		t.Skipf("SharedArrayBuffer unavailable: %v", err)
+	}
+
+	if _, ok := gs.ArrayBufferOf(b); ok {
+		t.Error("ArrayBufferOf accepted a SharedArrayBuffer")
+	}
+
+	if n, err := b.WriteAt([]byte{5, 6}, 1); n != 2 || err != nil {
+		t.Errorf("WriteAt: got %d, %v", n, err)
+	}
+
+	p := make([]byte, 3)
+	if n, err := b.ReadAt(p, 0); n != 3 || err != nil || !bytes.Equal(p, []byte{0, 5, 6}) {
+		t.Errorf("ReadAt: got %v, %d, %v", p, n, err)
+	}
+}
@@ -0,0 +1,180 @@
+//go:build wasm && js
+
+package gs_test
+
+import (
+	"testing"
+
+	"github.com/superloach/gs"
+)
+
+func numbers(vs ...int) []gs.Valuer {
+	out := make([]gs.Valuer, len(vs))
+	for i, v := range vs {
+		out[i] = gs.ValueOf(v)
+	}
+
+	return out
+}
+
+// must returns v, and panics if err is not nil, which fails the test.
+func must[T any](v T, err error) T {
+	if err != nil {
+		panic(err)
+	}
+
+	return v
+}
+
+// join returns the elements of a joined by commas.
+func join(t *testing.T, a gs.Array) string {
+	t.Helper()
+	return must(a.Join(","))
+}
+
+func TestArray(t *testing.T) {
+	a, err := gs.NewArray(numbers(3, 1, 2)...)
+	if err != nil {
+		t.Fatalf("new array: %v", err)
+	}
+
+	if a.Len() != 3 || must(a.At(-1)).Int() != 2 || !must(a.At(5)).IsUndefined() {
+		t.Errorf("Len/At: got %s", join(t, a))
+	}
+
+	if n := must(a.Push(numbers(4, 5)...)); n != 5 {
+		t.Errorf("Push: got %d", n)
+	}
+
+	if v := must(a.Pop()); v.Int() != 5 {
+		t.Errorf("Pop: got %v", v)
+	}
+
+	if v := must(a.Shift()); v.Int() != 3 {
+		t.Errorf("Shift: got %v", v)
+	}
+
+	if n := must(a.Unshift(numbers(0)...)); n != 4 {
+		t.Errorf("Unshift: got %d", n)
+	}
+
+	if s := must(a.Join("-")); s != "0-1-2-4" {
+		t.Errorf("Join: got %q", s)
+	}
+
+	if s := join(t, must(a.Slice(1, -1))); s != "1,2" {
+		t.Errorf("Slice: got %q", s)
+	}
+
+	if s := join(t, must(a.SliceFrom(-2))); s != "2,4" {
+		t.Errorf("SliceFrom: got %q", s)
+	}
+
+	removed := must(a.Splice(1, 2, gs.ToString("x")))
+	if join(t, removed) != "1,2" || join(t, a) != "0,x,4" {
+		t.Errorf("Splice: removed %q, left %q", join(t, removed), join(t, a))
+	}
+
+	b, _ := gs.NewArray(numbers(7)...)
+	if s := join(t, must(a.Concat(b, gs.ValueOf(8)))); s != "0,x,4,7,8" {
+		t.Errorf("Concat: got %q", s)
+	}
+
+	if must(a.IndexOf(gs.ToString("x"))) != 1 || must(a.IndexOf(gs.ValueOf(9))) != -1 {
+		t.Error("IndexOf")
+	}
+
+	withNaN, _ := gs.NewArray(gs.ValueNaN)
+	if !must(withNaN.Includes(gs.ValueNaN)) || must(withNaN.IndexOf(gs.ValueNaN)) != -1 {
+		t.Error("Includes should find NaN where IndexOf does not")
+	}
+
+	if s := join(t, must(a.Reverse())); s != "4,x,0" {
+		t.Errorf("Reverse: got %q", s)
+	}
+}
+
+func TestArrayThrows(t *testing.T) {
+	a, _ := gs.ArrayOf(eval(t, "[Symbol('s')]"))
+	if _, err := a.Join(","); err == nil {
+		t.Error("Join over a Symbol: expected an error")
+	}
+
+	a, _ = gs.ArrayOf(eval(t, "new Proxy([1], { get() { throw new Error('trap'); } })"))
+	if _, err := a.At(0); err == nil {
+		t.Error("At through a throwing Proxy: expected an error")
+	}
+
+	if _, err := a.IndexOf(gs.ValueOf(1)); err == nil {
+		t.Error("IndexOf through a throwing Proxy: expected an error")
+	}
+
+	a, _ = gs.NewArray(numbers(2, 1)...)
+	cmp := gs.Function{Value: eval(t, "(function () { throw new Error('cmp'); })")}
+	if _, err := a.Call("sort", cmp); err == nil {
+		t.Error("throwing comparator: expected an error")
+	}
+
+	frozen, _ := gs.ArrayOf(eval(t, "Object.freeze([2, 1])"))
+	if _, err := frozen.Sort(nil); err == nil {
+		t.Error("Sort of a frozen array: expected an error")
+	}
+}
+
+func TestArraySort(t *testing.T) {
+	a, _ := gs.NewArray(numbers(10, 9, 1, 100)...)
+
+	if _, err := a.Sort(nil); err != nil || join(t, a) != "1,10,100,9" {
+		t.Errorf("default sort: got %q, %v", join(t, a), err)
+	}
+
+	calls := 0
+	_, err := a.Sort(func(x, y gs.Value) int {
+		calls++
+		return y.Int() - x.Int()
+	})
+	if err != nil || join(t, a) != "100,10,9,1" {
+		t.Errorf("Go comparator: got %q, %v", join(t, a), err)
+	}
+
+	if calls == 0 {
+		t.Error("comparator never called")
+	}
+}
+
+func TestArrayOf(t *testing.T) {
+	if _, ok := gs.ArrayOf(eval(t, "({length: 0})")); ok {
+		t.Error("array-like object accepted as array")
+	}
+
+	set := eval(t, "new Set(['a', 'b'])")
+	a, err := gs.ArrayFrom(set)
+	if err != nil || must(a.Join("")) != "ab" {
+		t.Errorf("ArrayFrom: got %v, %v", a, err)
+	}
+
+	if _, ok := gs.ArrayOf(a); !ok {
+		t.Error("ArrayOf rejected an array")
+	}
+
+	if s := join(t, gs.Array{Object: gs.Object{Value: gs.ValueOf([]any{1, "a", nil})}}); s != "1,a," {
+		t.Errorf("ValueOf([]any): got %q", s)
+	}
+}
+
+func TestNewArrayLarge(t *testing.T) {
+	// more than fits in one call on some engines
+	elems := make([]int, 70000)
+	for i := range elems {
+		elems[i] = i
+	}
+
+	a, err := gs.NewArray(numbers(elems...)...)
+	if err != nil {
+		t.Fatalf("new array: %v", err)
+	}
+
+	if a.Len() != len(elems) || must(a.At(16384)).Int() != 16384 || must(a.At(-1)).Int() != len(elems)-1 {
+		t.Errorf("got %d elements", a.Len())
+	}
+}
@@ -0,0 +1,177 @@
+//go:build wasm && js
+
+package gs
+
+import (
+	"context"
+	"fmt"
+)
+
+var AbortSignalConstructor = Function{Value: Global.Get("AbortSignal")}
+
+// newPromise returns a new pending promise and its resolving functions.
+func newPromise() (p Promise, resolve, reject Function, err error) {
+	var res, rej Value
+	exec, err := WrapFunction(func(_ Value, args []Value) any {
+		res, rej = args[0], args[1]
+		return nil
+	})
+	if err != nil {
+		return Promise{}, Function{}, Function{}, fmt.Errorf("wrap executor: %w", err)
+	}
+	defer exec.Release()
+
+	// the executor runs before the constructor returns
+	v, err := PromiseConstructor.New(exec)
+	if err != nil {
+		return Promise{}, Function{}, Function{}, err
+	}
+
+	return Promise{Object: Object{Value: v}}, Function{Value: res}, Function{Value: rej}, nil
+}
+
+// settlePromise fulfills a promise from newPromise with res, marshaled to
+// JavaScript, or rejects it with err or the marshaling error. It is called
+// from goroutines, where a panic would take down the whole program, so it
+// never panics.
+func settlePromise(resolve, reject Function, res any, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			_, _ = reject.Invoke(ToString(fmt.Sprint("settle promise: ", r)))
+		}
+	}()
+
+	var v Value
+	if err == nil {
+		v, err = Marshal(res)
+	}
+
+	if err != nil {
+		_, _ = reject.Invoke(jsErrorOf(err))
+		return
+	}
+
+	_, _ = resolve.Invoke(v)
+}
+
+// abortSignalOf returns the AbortSignal among args, if any: either the last
+// argument, or the signal property of a plain object, whose prototype is
+// Object.prototype or null, in its place. A signal getter that throws counts
+// as no signal.
+func abortSignalOf(args []Value) (Object, bool) {
+	if len(args) == 0 || AbortSignalConstructor.IsUndefined() {
+		return Object{}, false
+	}
+
+	o, ok := ObjectOf(args[len(args)-1])
+	if !ok {
+		return Object{}, false
+	}
+
+	if !o.InstanceOf(AbortSignalConstructor.Value) {
+		if !isPlainObject(o) {
+			return Object{}, false
+		}
+
+		s, err := Reflect.Get(o, StringKey("signal"))
+		if err != nil {
+			return Object{}, false
+		}
+
+		o, ok = ObjectOf(s)
+		if !ok || !o.InstanceOf(AbortSignalConstructor.Value) {
+			return Object{}, false
+		}
+	}
+
+	return o, true
+}
+
+// isPlainObject reports whether o was created like an object literal or by
+// Object.create(null), so its properties are options rather than behaviour.
+func isPlainObject(o Object) bool {
+	proto, err := Reflect.GetPrototypeOf(o)
+	if err != nil {
+		return false
+	}
+
+	return proto.IsNull() || proto.Equal(Object{Value: ObjectConstructor.Value}.Get("prototype"))
+}
+
+// callAsync calls fn, returning a panic in fn as an error, since nothing
+// could recover it on the goroutine WrapAsync runs fn on.
+func callAsync(fn func(ctx context.Context, this Value, args []Value) (any, error), ctx context.Context, this Value, args []Value) (res any, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("panic: %v", r)
+		}
+	}()
+
+	return fn(ctx, this, args)
+}
+
+// WrapAsync returns an async function to be used by JavaScript. Each call
+// returns a promise, and runs fn on a new goroutine, so unlike a function
+// created by WrapFunction, fn may block.
+//
+// The promise is fulfilled with the result of fn converted by Marshal, or
+// rejected with its error (see PromiseReject), the error from Marshal, or an
+// error reporting a panic in fn.
+//
+// If the last argument is an AbortSignal, or a plain object such as an
+// options literal whose signal property is one, aborting it cancels ctx, with the Go error for the abort reason as
+// its cause. If fn then returns an error, the promise is rejected with the
+// abort reason, as JavaScript APIs like fetch do.
+//
+// Function.Release must be called to free up resources when the function will
+// not be invoked any more.
+func WrapAsync(fn func(ctx context.Context, this Value, args []Value) (any, error)) (Function, error) {
+	return wrapFunctionErr(func(this Value, args []Value) (any, error) {
+		p, resolve, reject, err := newPromise()
+		if err != nil {
+			return nil, err
+		}
+
+		ctx, cancel := context.WithCancelCause(context.Background())
+
+		signal, hasSignal := abortSignalOf(args)
+
+		var onAbort Function
+		if hasSignal {
+			onAbort, err = WrapFunction(func(Value, []Value) any {
+				cancel(thrownError(signal.Get("reason")))
+				return nil
+			})
+			if err != nil {
+				cancel(nil)
+				return nil, fmt.Errorf("wrap abort listener: %w", err)
+			}
+
+			if signal.Get("aborted").Truthy() {
+				cancel(thrownError(signal.Get("reason")))
+			} else if _, err := signal.Call("addEventListener", ToString("abort"), onAbort); err != nil {
+				onAbort.Release()
+				cancel(nil)
+				return nil, err
+			}
+		}
+
+		go func() {
+			res, err := callAsync(fn, ctx, this, args)
+
+			if hasSignal {
+				_, _ = signal.Call("removeEventListener", ToString("abort"), onAbort)
+				onAbort.Release()
+
+				if err != nil && signal.Get("aborted").Truthy() {
+					err = ThrownValue{Value: signal.Get("reason")}
+				}
+			}
+
+			cancel(nil)
+			settlePromise(resolve, reject, res, err)
+		}()
+
+		return p, nil
+	})
+}
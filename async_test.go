@@ -0,0 +1,140 @@
+//go:build wasm && js
+
+package gs_test
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/superloach/gs"
+)
+
+func TestWrapAsync(t *testing.T) {
+	f, err := gs.WrapAsync(func(ctx context.Context, this gs.Value, args []gs.Value) (any, error) {
+		// blocking is fine here
+		time.Sleep(time.Millisecond)
+
+		if len(args) == 0 {
+			return nil, errors.New("no arguments")
+		}
+
+		return args[0].Int() * 2, nil
+	})
+	if err != nil {
+		t.Fatalf("WrapAsync: %v", err)
+	}
+	defer f.Release()
+
+	res, err := f.Invoke(gs.ValueOf(21))
+	if err != nil {
+		t.Fatalf("invoke: %v", err)
+	}
+
+	p, ok := gs.PromiseOf(res)
+	if !ok {
+		t.Fatalf("invoke returned %v, not a promise", res)
+	}
+
+	if v, err := p.Await(context.Background()); err != nil || v.Int() != 42 {
+		t.Errorf("fulfilled: got %v, %v", v, err)
+	}
+
+	res, _ = f.Invoke()
+	p, _ = gs.PromiseOf(res)
+
+	if _, err := p.Await(context.Background()); err == nil || !strings.Contains(err.Error(), "no arguments") {
+		t.Errorf("rejected: got %v", err)
+	}
+}
+
+func TestWrapAsyncAbort(t *testing.T) {
+	cause := make(chan error, 1)
+
+	f, err := gs.WrapAsync(func(ctx context.Context, this gs.Value, args []gs.Value) (any, error) {
+		<-ctx.Done()
+		cause <- context.Cause(ctx)
+		return nil, ctx.Err()
+	})
+	if err != nil {
+		t.Fatalf("WrapAsync: %v", err)
+	}
+	defer f.Release()
+
+	call := gs.Function{Value: eval(t, `f => {
+		const c = new AbortController();
+		const p = f(1, { signal: c.signal });
+		setTimeout(() => c.abort(), 1);
+		return p;
+	}`)}
+
+	res, err := call.Invoke(f)
+	if err != nil {
+		t.Fatalf("invoke: %v", err)
+	}
+
+	p, _ := gs.PromiseOf(res)
+	_, err = p.Await(context.Background())
+
+	var jerr gs.Error
+	if !errors.As(err, &jerr) || jerr.Get("name").String() != "AbortError" {
+		t.Errorf("aborted: got %v", err)
+	}
+
+	if err := <-cause; !errors.As(err, &jerr) {
+		t.Errorf("context cause: got %v", err)
+	}
+}
+
+func TestWrapAsyncSignalGetter(t *testing.T) {
+	f, err := gs.WrapAsync(func(ctx context.Context, this gs.Value, args []gs.Value) (any, error) {
+		return ctx.Err() == nil, nil
+	})
+	if err != nil {
+		t.Fatalf("WrapAsync: %v", err)
+	}
+	defer f.Release()
+
+	for _, src := range []string{
+		"({ get signal() { throw new Error('no'); } })",
+		"new (class { get signal() { return AbortSignal.abort(); } })",
+	} {
+		res, err := f.Invoke(eval(t, src))
+		if err != nil {
+			t.Fatalf("invoke with %s: %v", src, err)
+		}
+
+		p, _ := gs.PromiseOf(res)
+		if v, err := p.Await(context.Background()); err != nil || !v.Truthy() {
+			t.Errorf("%s: got %v, %v", src, v, err)
+		}
+	}
+}
+
+func TestWrapAsyncBadResult(t *testing.T) {
+	f, err := gs.WrapAsync(func(ctx context.Context, this gs.Value, args []gs.Value) (any, error) {
+		if len(args) > 0 {
+			panic("boom")
+		}
+
+		return make(chan int), nil
+	})
+	if err != nil {
+		t.Fatalf("WrapAsync: %v", err)
+	}
+	defer f.Release()
+
+	for _, args := range [][]gs.Valuer{nil, {gs.ValueOf(1)}} {
+		res, err := f.Invoke(args...)
+		if err != nil {
+			t.Fatalf("invoke: %v", err)
+		}
+
+		p, _ := gs.PromiseOf(res)
+		if _, err := p.Await(context.Background()); err == nil {
+			t.Errorf("args %v: expected a rejection", args)
+		}
+	}
+}
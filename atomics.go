@@ -0,0 +1,285 @@
+//go:build wasm && js
+
+package gs
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"time"
+)
+
+var Atomics = AtomicsType{
+	Object: Object{
+		Value: Global.Get("Atomics"),
+	},
+}
+
+// AtomicsType is the type of the Atomics namespace object, which provides
+// atomic operations on typed arrays.
+//
+// The methods take Int32Array or BigInt64Array views, which must be views of
+// a SharedArrayBuffer for the operations to be seen by other workers. Like Go
+// slice indexing, they panic if the index is out of range.
+type AtomicsType struct {
+	Object
+}
+
+// WaitResult is the outcome of Atomics.wait or Atomics.waitAsync.
+type WaitResult string
+
+const (
+	// WaitOK means the waiter was woken by Atomics.notify.
+	WaitOK WaitResult = "ok"
+
+	// WaitNotEqual means the value was not the expected one, so the waiter
+	// did not sleep.
+	WaitNotEqual WaitResult = "not-equal"
+
+	// WaitTimedOut means the timeout passed before the waiter was woken.
+	WaitTimedOut WaitResult = "timed-out"
+)
+
+func (a AtomicsType) call(m string, args ...Valuer) Value {
+	res, err := a.Call(m, args...)
+	if err != nil {
+		panic("Atomics." + m + ": " + err.Error())
+	}
+
+	return res
+}
+
+func bigIntInt64(v Value) int64 {
+	b, ok := BigIntOf(v)
+	if !ok {
+		panic("expected a bigint")
+	}
+
+	x, _ := b.Int64()
+	return x
+}
+
+// waitTimeout converts timeout to milliseconds, where a negative timeout
+// waits forever.
+func waitTimeout(timeout time.Duration) Value {
+	if timeout < 0 {
+		return FloatValue(math.Inf(1))
+	}
+
+	return FloatValue(float64(timeout) / float64(time.Millisecond))
+}
+
+// notifyCount converts count to the count for Atomics.notify, where a
+// negative count wakes every waiter.
+func notifyCount(count int) Value {
+	if count < 0 {
+		return FloatValue(math.Inf(1))
+	}
+
+	return ValueOf(count)
+}
+
+// Load returns element i of arr.
+func (a AtomicsType) Load(arr Int32Array, i int) int32 {
+	return int32(a.call("load", arr, ValueOf(i)).Int())
+}
+
+// Load64 returns element i of arr.
+func (a AtomicsType) Load64(arr BigInt64Array, i int) int64 {
+	return bigIntInt64(a.call("load", arr, ValueOf(i)))
+}
+
+// Store sets element i of arr to x.
+func (a AtomicsType) Store(arr Int32Array, i int, x int32) {
+	a.call("store", arr, ValueOf(i), ValueOf(x))
+}
+
+// Store64 sets element i of arr to x.
+func (a AtomicsType) Store64(arr BigInt64Array, i int, x int64) {
+	a.call("store", arr, ValueOf(i), NewBigIntInt64(x))
+}
+
+// Add adds delta to element i of arr, wrapping on overflow, and returns the
+// old value.
+func (a AtomicsType) Add(arr Int32Array, i int, delta int32) int32 {
+	return int32(a.call("add", arr, ValueOf(i), ValueOf(delta)).Int())
+}
+
+// Add64 adds delta to element i of arr, wrapping on overflow, and returns the
+// old value.
+func (a AtomicsType) Add64(arr BigInt64Array, i int, delta int64) int64 {
+	return bigIntInt64(a.call("add", arr, ValueOf(i), NewBigIntInt64(delta)))
+}
+
+// CompareExchange sets element i of arr to repl if it is old, and returns the
+// value it had, which equals old if the exchange happened.
+func (a AtomicsType) CompareExchange(arr Int32Array, i int, old, repl int32) int32 {
+	return int32(a.call("compareExchange", arr, ValueOf(i), ValueOf(old), ValueOf(repl)).Int())
+}
+
+// CompareExchange64 sets element i of arr to repl if it is old, and returns
+// the value it had, which equals old if the exchange happened.
+func (a AtomicsType) CompareExchange64(arr BigInt64Array, i int, old, repl int64) int64 {
+	return bigIntInt64(a.call("compareExchange", arr, ValueOf(i), NewBigIntInt64(old), NewBigIntInt64(repl)))
+}
+
+// Notify wakes up to count waiters on element i of arr, or all of them if
+// count is negative, and returns the number woken.
+func (a AtomicsType) Notify(arr Int32Array, i, count int) int {
+	return a.call("notify", arr, ValueOf(i), notifyCount(count)).Int()
+}
+
+// Notify64 wakes up to count waiters on element i of arr, or all of them if
+// count is negative, and returns the number woken.
+func (a AtomicsType) Notify64(arr BigInt64Array, i, count int) int {
+	return a.call("notify", arr, ValueOf(i), notifyCount(count)).Int()
+}
+
+// Wait sleeps while element i of arr is value, until notified or timeout
+// passes; a negative timeout waits forever.
+//
+// Wait blocks the whole JavaScript thread, and with it every goroutine, so it
+// is only useful in a worker waiting on another one. Browsers do not allow it
+// on the main thread, which is returned as an error. Prefer WaitAsync.
+func (a AtomicsType) Wait(arr Int32Array, i int, value int32, timeout time.Duration) (WaitResult, error) {
+	res, err := a.Call("wait", arr, ValueOf(i), ValueOf(value), waitTimeout(timeout))
+	if err != nil {
+		return "", err
+	}
+
+	return WaitResult(jsString(res)), nil
+}
+
+// Wait64 is like Wait, for a BigInt64Array.
+func (a AtomicsType) Wait64(arr BigInt64Array, i int, value int64, timeout time.Duration) (WaitResult, error) {
+	res, err := a.Call("wait", arr, ValueOf(i), NewBigIntInt64(value), waitTimeout(timeout))
+	if err != nil {
+		return "", err
+	}
+
+	return WaitResult(jsString(res)), nil
+}
+
+// WaitAsync is like Wait, but does not block. The result is sent on the
+// returned channel once the wait ends, so goroutines can wait on it while
+// the JavaScript thread keeps running.
+func (a AtomicsType) WaitAsync(arr Int32Array, i int, value int32, timeout time.Duration) (<-chan WaitResult, error) {
+	return a.waitAsync(arr, ValueOf(i), ValueOf(value), waitTimeout(timeout))
+}
+
+// WaitAsync64 is like WaitAsync, for a BigInt64Array.
+func (a AtomicsType) WaitAsync64(arr BigInt64Array, i int, value int64, timeout time.Duration) (<-chan WaitResult, error) {
+	return a.waitAsync(arr, ValueOf(i), NewBigIntInt64(value), waitTimeout(timeout))
+}
+
+func (a AtomicsType) waitAsync(args ...Valuer) (<-chan WaitResult, error) {
+	res, err := a.Call("waitAsync", args...)
+	if err != nil {
+		return nil, err
+	}
+
+	ch := make(chan WaitResult, 1)
+
+	r := Object{Value: res}
+	if !r.Get("async").Truthy() {
+		ch <- WaitResult(jsString(r.Get("value")))
+		return ch, nil
+	}
+
+	var done Function
+	done, err = WrapFunction(func(_ Value, args []Value) any {
+		ch <- WaitResult(jsString(args[0]))
+		done.Release()
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("wrap callback: %w", err)
+	}
+
+	if _, err := (Object{Value: r.Get("value")}).Call("then", done); err != nil {
+		done.Release()
+		return nil, err
+	}
+
+	return ch, nil
+}
+
+// Futex is a futex-like primitive on an element of a shared Int32Array, for
+// signaling between Go and JavaScript workers. Waiting uses Atomics.waitAsync,
+// so it blocks only the calling goroutine.
+type Futex struct {
+	arr Int32Array
+	i   int
+}
+
+// NewFutex returns a Futex on element i of arr, which should be a view of a
+// SharedArrayBuffer.
+func NewFutex(arr Int32Array, i int) Futex {
+	return Futex{arr: arr, i: i}
+}
+
+// Load returns the value of f.
+func (f Futex) Load() int32 {
+	return Atomics.Load(f.arr, f.i)
+}
+
+// Store sets the value of f to x.
+func (f Futex) Store(x int32) {
+	Atomics.Store(f.arr, f.i, x)
+}
+
+// Add adds delta to the value of f and returns the new value.
+func (f Futex) Add(delta int32) int32 {
+	return Atomics.Add(f.arr, f.i, delta) + delta
+}
+
+// CompareAndSwap sets the value of f to repl if it is old, and reports
+// whether it did.
+func (f Futex) CompareAndSwap(old, repl int32) bool {
+	return Atomics.CompareExchange(f.arr, f.i, old, repl) == old
+}
+
+// Wait blocks the calling goroutine while the value of f is value, until it
+// is woken by Wake or Atomics.notify, or ctx is done. Like a futex, it may
+// return spuriously, so callers should check the value again.
+//
+// If ctx is done first, the JavaScript waiter stays queued until it is woken
+// or ctx's deadline passes. A wakeup it takes in that time is passed on to
+// the next waiter, so it is not lost.
+func (f Futex) Wait(ctx context.Context, value int32) error {
+	timeout := time.Duration(-1)
+	if deadline, ok := ctx.Deadline(); ok {
+		timeout = time.Until(deadline)
+		if timeout < 0 {
+			timeout = 0
+		}
+	}
+
+	ch, err := Atomics.WaitAsync(f.arr, f.i, value, timeout)
+	if err != nil {
+		return err
+	}
+
+	select {
+	case r := <-ch:
+		if r == WaitTimedOut {
+			return context.DeadlineExceeded
+		}
+
+		return nil
+	case <-ctx.Done():
+		go func() {
+			if <-ch == WaitOK {
+				f.Wake(1)
+			}
+		}()
+
+		return ctx.Err()
+	}
+}
+
+// Wake wakes up to n waiters on f, or all of them if n is negative, and
+// returns the number woken.
+func (f Futex) Wake(n int) int {
+	return Atomics.Notify(f.arr, f.i, n)
+}
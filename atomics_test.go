@@ -0,0 +1,138 @@
+//go:build wasm && js
+
+package gs_test
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/superloach/gs"
+)
+
+func sharedInt32Array(t *testing.T, n int) gs.Int32Array {
+	t.Helper()
+
+	if gs.SharedArrayBufferConstructor.IsUndefined() {
+		t.Skip("SharedArrayBuffer unavailable")
+	}
+
+	a, ok := gs.Int32ArrayOf(eval(t, "new Int32Array(new SharedArrayBuffer(4 * "+strconv.Itoa(n)+"))"))
+	if !ok {
+		t.Fatal("not an Int32Array")
+	}
+
+	return a
+}
+
+func TestAtomics(t *testing.T) {
+	a := sharedInt32Array(t, 2)
+
+	gs.Atomics.Store(a, 1, 40)
+	if old := gs.Atomics.Add(a, 1, 2); old != 40 || gs.Atomics.Load(a, 1) != 42 {
+		t.Errorf("Add: old %d, new %d", old, gs.Atomics.Load(a, 1))
+	}
+
+	if got := gs.Atomics.CompareExchange(a, 1, 0, 7); got != 42 {
+		t.Errorf("failed CompareExchange: got %d", got)
+	}
+
+	if got := gs.Atomics.CompareExchange(a, 1, 42, -7); got != 42 || gs.Atomics.Load(a, 1) != -7 {
+		t.Errorf("CompareExchange: got %d", got)
+	}
+
+	if r, err := gs.Atomics.Wait(a, 0, 1, -1); r != gs.WaitNotEqual || err != nil {
+		t.Errorf("Wait on other value: got %q, %v", r, err)
+	}
+
+	if r, err := gs.Atomics.Wait(a, 0, 0, time.Millisecond); r != gs.WaitTimedOut || err != nil {
+		t.Errorf("Wait with timeout: got %q, %v", r, err)
+	}
+}
+
+func TestAtomics64(t *testing.T) {
+	if gs.SharedArrayBufferConstructor.IsUndefined() || gs.BigInt64ArrayConstructor.IsUndefined() {
+		t.Skip("SharedArrayBuffer or BigInt64Array unavailable")
+	}
+
+	a, ok := gs.BigInt64ArrayOf(eval(t, "new BigInt64Array(new SharedArrayBuffer(8))"))
+	if !ok {
+		t.Fatal("not a BigInt64Array")
+	}
+
+	gs.Atomics.Store64(a, 0, 1<<40)
+	if old := gs.Atomics.Add64(a, 0, -1); old != 1<<40 || gs.Atomics.Load64(a, 0) != 1<<40-1 {
+		t.Errorf("Add64: old %d, new %d", old, gs.Atomics.Load64(a, 0))
+	}
+}
+
+func TestFutex(t *testing.T) {
+	f := gs.NewFutex(sharedInt32Array(t, 1), 0)
+
+	done := make(chan error)
+	go func() {
+		for f.Load() == 0 {
+			if err := f.Wait(context.Background(), 0); err != nil {
+				done <- err
+				return
+			}
+		}
+
+		done <- nil
+	}()
+
+	// let the waiter queue up
+	time.Sleep(10 * time.Millisecond)
+
+	if !f.CompareAndSwap(0, 1) {
+		t.Fatal("CompareAndSwap failed")
+	}
+
+	if n := f.Wake(-1); n != 1 {
+		t.Errorf("Wake: woke %d", n)
+	}
+
+	if err := <-done; err != nil {
+		t.Errorf("Wait: %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Millisecond)
+	defer cancel()
+
+	if err := f.Wait(ctx, 1); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Wait with deadline: got %v", err)
+	}
+}
+
+func TestFutexCancel(t *testing.T) {
+	f := gs.NewFutex(sharedInt32Array(t, 1), 0)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	if err := f.Wait(ctx, 0); !errors.Is(err, context.Canceled) {
+		t.Fatalf("Wait with cancelled context: got %v", err)
+	}
+
+	done := make(chan error)
+	go func() {
+		done <- f.Wait(context.Background(), 0)
+	}()
+
+	// let the second waiter queue up behind the cancelled one
+	time.Sleep(10 * time.Millisecond)
+
+	// the cancelled waiter is first in line, and passes the wakeup on
+	f.Wake(1)
+
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Errorf("Wait: %v", err)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("wakeup was lost to the cancelled waiter")
+	}
+}
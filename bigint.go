@@ -2,28 +2,306 @@
 
 package gs
 
+import (
+	"errors"
+	"math/big"
+	"strconv"
+)
+
 var _ Valuer = BigInt{}
 
-// TODO: implement BigInt operations (6.1.6.2)
+var BigIntConstructor = Function{Value: Global.Get("BigInt")}
+
+// bigIntZero is kept alive for the life of the program, so every 0n shares its
+// reference (see Value.Equal).
+var bigIntZero = NewBigIntInt64(0)
+
+var (
+	// ErrBigIntDivideByZero is returned when dividing a BigInt by 0n, where
+	// JavaScript would throw a RangeError.
+	ErrBigIntDivideByZero = errors.New("gs: BigInt division by zero")
+
+	// ErrBigIntNegativeExponent is returned when raising a BigInt to a
+	// negative power, where JavaScript would throw a RangeError.
+	ErrBigIntNegativeExponent = errors.New("gs: BigInt negative exponent")
+
+	// ErrBigIntTooLarge is returned when the result of a BigInt operation is
+	// too large to represent, where JavaScript would throw a RangeError.
+	ErrBigIntTooLarge = errors.New("gs: BigInt too large")
+)
+
+// BigInt is a JavaScript bigint primitive.
+//
+// The arithmetic methods implement the BigInt operations of the
+// specification (6.1.6.2) in Go, so they never lose precision and never
+// throw. Operations that would throw a RangeError in JavaScript return an
+// error instead.
 type BigInt struct {
 	Value
 }
 
 // BigIntOf converts a JavaScript value into a BigInt, if possible.
 //
-// TODO: coerce non-bigint values into bigints?
+// Bigints are returned as-is. Other values are coerced with the global
+// BigInt function, which accepts booleans, integral numbers and strings of
+// digits; ok is false if the coercion throws.
 func BigIntOf(v Valuer) (BigInt, bool) {
 	vv := v.ValueOf()
 
-	if vv.Type() != TypeBigInt {
+	if vv.Type() == TypeBigInt {
+		return BigInt{Value: vv}, true
+	}
+
+	switch vv.Type() {
+	case TypeBoolean, TypeNumber, TypeString:
+	default:
+		return BigInt{}, false
+	}
+
+	res, err := BigIntConstructor.Invoke(vv)
+	if err != nil {
 		return BigInt{}, false
 	}
 
-	return BigInt{
-		Value: vv,
-	}, true
+	return BigInt{Value: res}, true
+}
+
+// NewBigInt returns x as a JavaScript bigint.
+func NewBigInt(x *big.Int) BigInt {
+	return newBigInt(x.String())
+}
+
+// NewBigIntInt64 returns x as a JavaScript bigint.
+func NewBigIntInt64(x int64) BigInt {
+	return newBigInt(strconv.FormatInt(x, 10))
+}
+
+// NewBigIntUint64 returns x as a JavaScript bigint.
+func NewBigIntUint64(x uint64) BigInt {
+	return newBigInt(strconv.FormatUint(x, 10))
+}
+
+func newBigInt(digits string) BigInt {
+	res, err := BigIntConstructor.Invoke(ToString(digits))
+	if err != nil {
+		panic("BigInt(" + digits + "): " + err.Error())
+	}
+
+	return BigInt{Value: res}
 }
 
 func (b BigInt) ValueOf() Value {
 	return b.Value
 }
+
+// Big returns b as a *big.Int.
+func (b BigInt) Big() *big.Int {
+	s, err := StringConstructor.Invoke(b)
+	if err != nil {
+		panic("String(bigint): " + err.Error())
+	}
+
+	x, ok := new(big.Int).SetString(jsString(s), 10)
+	if !ok {
+		panic("bad bigint digits")
+	}
+
+	return x
+}
+
+// Int64 returns b as an int64. ok is false if b does not fit in an int64.
+func (b BigInt) Int64() (x int64, ok bool) {
+	bx := b.Big()
+	if !bx.IsInt64() {
+		return 0, false
+	}
+
+	return bx.Int64(), true
+}
+
+// Uint64 returns b as a uint64. ok is false if b does not fit in a uint64.
+func (b BigInt) Uint64() (x uint64, ok bool) {
+	bx := b.Big()
+	if !bx.IsUint64() {
+		return 0, false
+	}
+
+	return bx.Uint64(), true
+}
+
+// UnaryMinus returns -b.
+func (b BigInt) UnaryMinus() BigInt {
+	return NewBigInt(new(big.Int).Neg(b.Big()))
+}
+
+// BitwiseNOT returns ~b.
+func (b BigInt) BitwiseNOT() BigInt {
+	return NewBigInt(new(big.Int).Not(b.Big()))
+}
+
+// Exponentiate returns b ** e. It returns ErrBigIntNegativeExponent if e is
+// negative, and ErrBigIntTooLarge if the result would not fit in memory, by
+// the same bound as LeftShift.
+func (b BigInt) Exponentiate(e BigInt) (BigInt, error) {
+	be := e.Big()
+	if be.Sign() < 0 {
+		return BigInt{}, ErrBigIntNegativeExponent
+	}
+
+	bb := b.Big()
+
+	// 0, 1 and -1 stay small whatever the exponent
+	if bb.CmpAbs(big.NewInt(1)) <= 0 {
+		switch {
+		case be.Sign() == 0:
+			return NewBigIntInt64(1), nil
+		case bb.Sign() < 0 && be.Bit(0) == 0:
+			return NewBigIntInt64(1), nil
+		default:
+			return b, nil
+		}
+	}
+
+	// the result has more than (bitlen(b)-1)*e bits, as 2**n has n+1
+	if !be.IsInt64() || be.Int64() > maxShift || int64(bb.BitLen()-1)*be.Int64() > maxShift {
+		return BigInt{}, ErrBigIntTooLarge
+	}
+
+	return NewBigInt(new(big.Int).Exp(bb, be, nil)), nil
+}
+
+// Multiply returns b * y.
+func (b BigInt) Multiply(y BigInt) BigInt {
+	return NewBigInt(new(big.Int).Mul(b.Big(), y.Big()))
+}
+
+// Divide returns b / y, truncated towards zero. It returns
+// ErrBigIntDivideByZero if y is 0n.
+func (b BigInt) Divide(y BigInt) (BigInt, error) {
+	by := y.Big()
+	if by.Sign() == 0 {
+		return BigInt{}, ErrBigIntDivideByZero
+	}
+
+	return NewBigInt(new(big.Int).Quo(b.Big(), by)), nil
+}
+
+// Remainder returns b % y, which has the sign of b. It returns
+// ErrBigIntDivideByZero if y is 0n.
+func (b BigInt) Remainder(y BigInt) (BigInt, error) {
+	by := y.Big()
+	if by.Sign() == 0 {
+		return BigInt{}, ErrBigIntDivideByZero
+	}
+
+	return NewBigInt(new(big.Int).Rem(b.Big(), by)), nil
+}
+
+// Add returns b + y.
+func (b BigInt) Add(y BigInt) BigInt {
+	return NewBigInt(new(big.Int).Add(b.Big(), y.Big()))
+}
+
+// Subtract returns b - y.
+func (b BigInt) Subtract(y BigInt) BigInt {
+	return NewBigInt(new(big.Int).Sub(b.Big(), y.Big()))
+}
+
+// LeftShift returns b << y. A negative y shifts right. It returns
+// ErrBigIntTooLarge if the result would not fit in memory.
+func (b BigInt) LeftShift(y BigInt) (BigInt, error) {
+	x, err := shift(b.Big(), y.Big())
+	if err != nil {
+		return BigInt{}, err
+	}
+
+	return NewBigInt(x), nil
+}
+
+// SignedRightShift returns b >> y. A negative y shifts left. It returns
+// ErrBigIntTooLarge if the result would not fit in memory.
+func (b BigInt) SignedRightShift(y BigInt) (BigInt, error) {
+	x, err := shift(b.Big(), new(big.Int).Neg(y.Big()))
+	if err != nil {
+		return BigInt{}, err
+	}
+
+	return NewBigInt(x), nil
+}
+
+// maxShift bounds left shifts, well past what any JavaScript engine will
+// allocate for a bigint.
+const maxShift = 1 << 30
+
+// shift returns x * 2**n, rounded towards negative infinity.
+func shift(x, n *big.Int) (*big.Int, error) {
+	if n.Sign() < 0 {
+		if !n.IsInt64() || n.Int64() < -maxShift {
+			// nothing but the sign survives
+			if x.Sign() < 0 {
+				return big.NewInt(-1), nil
+			}
+
+			return new(big.Int), nil
+		}
+
+		return new(big.Int).Rsh(x, uint(-n.Int64())), nil
+	}
+
+	if x.Sign() == 0 {
+		return new(big.Int), nil
+	}
+
+	if !n.IsInt64() || n.Int64() > maxShift {
+		return nil, ErrBigIntTooLarge
+	}
+
+	return new(big.Int).Lsh(x, uint(n.Int64())), nil
+}
+
+// BitwiseAND returns b & y.
+func (b BigInt) BitwiseAND(y BigInt) BigInt {
+	return NewBigInt(new(big.Int).And(b.Big(), y.Big()))
+}
+
+// BitwiseXOR returns b ^ y.
+func (b BigInt) BitwiseXOR(y BigInt) BigInt {
+	return NewBigInt(new(big.Int).Xor(b.Big(), y.Big()))
+}
+
+// BitwiseOR returns b | y.
+func (b BigInt) BitwiseOR(y BigInt) BigInt {
+	return NewBigInt(new(big.Int).Or(b.Big(), y.Big()))
+}
+
+// Compare returns -1, 0 or +1 depending on whether b is less than, equal to
+// or greater than y.
+func (b BigInt) Compare(y BigInt) int {
+	return b.Big().Cmp(y.Big())
+}
+
+// LessThan reports whether b < y.
+func (b BigInt) LessThan(y BigInt) bool {
+	return b.Compare(y) < 0
+}
+
+// AsIntN wraps b to a signed integer of the given number of bits, like
+// JavaScript's BigInt.asIntN.
+func (b BigInt) AsIntN(bits int) BigInt {
+	return b.asN("asIntN", bits)
+}
+
+// AsUintN wraps b to an unsigned integer of the given number of bits, like
+// JavaScript's BigInt.asUintN.
+func (b BigInt) AsUintN(bits int) BigInt {
+	return b.asN("asUintN", bits)
+}
+
+func (b BigInt) asN(m string, bits int) BigInt {
+	res, err := Object{Value: BigIntConstructor.Value}.Call(m, ValueOf(bits), b)
+	if err != nil {
+		panic("BigInt." + m + ": " + err.Error())
+	}
+
+	return BigInt{Value: res}
+}
@@ -1,6 +1,9 @@
 package gs_test
 
 import (
+	"errors"
+	"math"
+	"math/big"
 	"testing"
 
 	"github.com/superloach/gs"
@@ -18,3 +21,168 @@ func TestBigInt(t *testing.T) {
 		t.Fatalf("expected bigint, got %v", ty)
 	}
 }
+
+func TestBigIntRoundTrip(t *testing.T) {
+	x, _ := new(big.Int).SetString("-123456789012345678901234567890", 10)
+
+	if got := gs.NewBigInt(x).Big(); got.Cmp(x) != 0 {
+		t.Errorf("got %v, want %v", got, x)
+	}
+
+	if got, ok := gs.NewBigIntUint64(math.MaxUint64).Uint64(); !ok || got != math.MaxUint64 {
+		t.Errorf("got %v, %v", got, ok)
+	}
+
+	if _, ok := gs.NewBigIntUint64(math.MaxUint64).Int64(); ok {
+		t.Error("expected MaxUint64 to overflow int64")
+	}
+
+	if b, ok := gs.BigIntOf(gs.ToString("42")); !ok || b.Big().Int64() != 42 {
+		t.Errorf("BigIntOf(\"42\") = %v, %v", b, ok)
+	}
+
+	if _, ok := gs.BigIntOf(gs.ValueOf(1.5)); ok {
+		t.Error("expected BigIntOf(1.5) to fail")
+	}
+}
+
+func TestBigIntOperations(t *testing.T) {
+	n := gs.NewBigIntInt64
+
+	check := func(name string, got gs.BigInt, want int64) {
+		t.Helper()
+		if x, _ := got.Int64(); x != want {
+			t.Errorf("%s: got %d, want %d", name, x, want)
+		}
+	}
+
+	check("add", n(7).Add(n(-9)), -2)
+	check("subtract", n(7).Subtract(n(9)), -2)
+	check("multiply", n(-7).Multiply(n(9)), -63)
+	check("unaryMinus", n(7).UnaryMinus(), -7)
+	check("bitwiseNOT", n(7).BitwiseNOT(), -8)
+	check("bitwiseAND", n(6).BitwiseAND(n(3)), 2)
+	check("bitwiseOR", n(6).BitwiseOR(n(3)), 7)
+	check("bitwiseXOR", n(6).BitwiseXOR(n(3)), 5)
+	check("asIntN", n(255).AsIntN(8), -1)
+	check("asUintN", n(-1).AsUintN(8), 255)
+
+	q, err := n(-7).Divide(n(2))
+	if err != nil {
+		t.Fatal(err)
+	}
+	check("divide", q, -3)
+
+	r, err := n(-7).Remainder(n(2))
+	if err != nil {
+		t.Fatal(err)
+	}
+	check("remainder", r, -1)
+
+	e, err := n(3).Exponentiate(n(4))
+	if err != nil {
+		t.Fatal(err)
+	}
+	check("exponentiate", e, 81)
+
+	l, err := n(3).LeftShift(n(4))
+	if err != nil {
+		t.Fatal(err)
+	}
+	check("leftShift", l, 48)
+
+	s, err := n(-7).SignedRightShift(n(1))
+	if err != nil {
+		t.Fatal(err)
+	}
+	check("signedRightShift", s, -4)
+
+	if _, err := n(1).Divide(n(0)); !errors.Is(err, gs.ErrBigIntDivideByZero) {
+		t.Errorf("divide by zero: got %v", err)
+	}
+
+	if _, err := n(1).Exponentiate(n(-1)); !errors.Is(err, gs.ErrBigIntNegativeExponent) {
+		t.Errorf("negative exponent: got %v", err)
+	}
+
+	huge, _ := n(1).LeftShift(n(40))
+	if _, err := n(2).Exponentiate(huge); !errors.Is(err, gs.ErrBigIntTooLarge) {
+		t.Errorf("huge exponent: got %v", err)
+	}
+
+	if e, err := n(-1).Exponentiate(huge); err != nil || e.Compare(n(1)) != 0 {
+		t.Errorf("(-1) ** huge: got %v, %v", e, err)
+	}
+
+	if !n(-1).LessThan(n(0)) || n(1).Compare(n(1)) != 0 {
+		t.Error("comparison")
+	}
+}
+
+func TestBigIntValue(t *testing.T) {
+	v := eval(t, "2n ** 64n")
+
+	if ty := v.Type(); ty != gs.TypeBigInt {
+		t.Fatalf("expected bigint, got %v", ty)
+	}
+
+	if s := v.String(); s != "<bigint: 18446744073709551616>" {
+		t.Errorf("String: got %q", s)
+	}
+
+	if !v.Truthy() || eval(t, "0n").Truthy() {
+		t.Error("Truthy: expected only 0n to be falsy")
+	}
+
+	if !v.Equal(eval(t, "18446744073709551616n")) || v.Equal(eval(t, "1n")) {
+		t.Error("Equal: expected bigints to compare by value")
+	}
+
+	x, _ := new(big.Int).SetString("18446744073709551616", 10)
+	if !gs.ValueOf(x).Equal(v) {
+		t.Error("ValueOf(*big.Int): expected equal bigint")
+	}
+}
+
+type bigIntRecord struct {
+	ID     uint64   `js:"id"`
+	Amount *big.Int `js:"amount"`
+	Any    any      `js:"any"`
+}
+
+func TestBigIntMarshal(t *testing.T) {
+	amount, _ := new(big.Int).SetString("-98765432109876543210", 10)
+
+	v, err := gs.Marshal(bigIntRecord{Amount: amount})
+	if err != nil {
+		t.Fatalf("marshal: %v", err)
+	}
+
+	o, _ := gs.ObjectOf(v)
+	if ty := o.Get("amount").Type(); ty != gs.TypeBigInt {
+		t.Fatalf("expected bigint amount, got %v", ty)
+	}
+
+	var got bigIntRecord
+	src := eval(t, "({id: 18446744073709551615n, amount: -98765432109876543210n, any: 5n})")
+	if err := gs.Unmarshal(src, &got); err != nil {
+		t.Fatalf("unmarshal: %v", err)
+	}
+
+	if got.ID != math.MaxUint64 || got.Amount.Cmp(amount) != 0 {
+		t.Errorf("got %+v", got)
+	}
+
+	if b, ok := got.Any.(*big.Int); !ok || b.Int64() != 5 {
+		t.Errorf("any: got %#v", got.Any)
+	}
+
+	var small struct {
+		ID int8 `js:"id"`
+	}
+
+	var ute *gs.UnmarshalTypeError
+	if err := gs.Unmarshal(src, &small); !errors.As(err, &ute) || ute.Value != "bigint 18446744073709551615" {
+		t.Errorf("expected range error, got %v", err)
+	}
+}
@@ -0,0 +1,232 @@
+//go:build wasm && js
+
+package gs
+
+import (
+	"io"
+	"math"
+)
+
+var DataViewConstructor = Function{Value: Global.Get("DataView")}
+
+var (
+	_ io.ReaderAt = DataView{}
+	_ io.WriterAt = DataView{}
+)
+
+// DataView is a JavaScript DataView, which reads and writes numbers of any
+// width and byte order at arbitrary offsets of a buffer.
+//
+// Like Go slice indexing, the getters and setters panic if the offset is out
+// of range.
+//
+// Code written against encoding/binary can use a DataView in place through
+// ReadAt and WriteAt, such as binary.Read from an io.SectionReader.
+type DataView struct {
+	Object
+}
+
+// DataViewOf converts a JavaScript value into a DataView, if it is one.
+func DataViewOf(v Valuer) (DataView, bool) {
+	o, ok := ObjectOf(v)
+	if !ok || !o.InstanceOf(DataViewConstructor.Value) {
+		return DataView{}, false
+	}
+
+	return DataView{Object: o}, true
+}
+
+// NewDataView returns a DataView of length bytes of buffer from offset.
+// buffer must be an ArrayBuffer or SharedArrayBuffer. If length is negative,
+// the view extends to the end of the buffer, tracking its length if it is
+// resizable.
+func NewDataView(buffer Valuer, offset, length int) (DataView, error) {
+	args := []Valuer{buffer, ValueOf(offset)}
+	if length >= 0 {
+		args = append(args, ValueOf(length))
+	}
+
+	v, err := DataViewConstructor.New(args...)
+	if err != nil {
+		return DataView{}, err
+	}
+
+	return DataView{Object: Object{Value: v}}, nil
+}
+
+func (v DataView) ValueOf() Value {
+	return v.Value
+}
+
+// Buffer returns the buffer that v is a view of, which is either an
+// ArrayBuffer or a SharedArrayBuffer.
+func (v DataView) Buffer() Object {
+	return Object{Value: v.Get("buffer")}
+}
+
+// ByteOffset returns the offset in bytes of v from the start of its buffer.
+func (v DataView) ByteOffset() int {
+	return v.Get("byteOffset").Int()
+}
+
+// ByteLength returns the length in bytes of v.
+func (v DataView) ByteLength() int {
+	return v.Get("byteLength").Int()
+}
+
+func (v DataView) call(m string, args ...Valuer) Value {
+	res, err := v.Call(m, args...)
+	if err != nil {
+		panic("DataView.prototype." + m + ": " + err.Error())
+	}
+
+	return res
+}
+
+func (v DataView) get(m string, off int, littleEndian bool) Value {
+	return v.call(m, ValueOf(off), ValueOf(littleEndian))
+}
+
+func (v DataView) set(m string, off int, x any, littleEndian bool) {
+	v.call(m, ValueOf(off), ValueOf(x), ValueOf(littleEndian))
+}
+
+// checkRange panics like method m would if the n bytes at offset off are not
+// all within v, for accesses split into several calls.
+func (v DataView) checkRange(m string, off, n int) {
+	if off < 0 || off > v.ByteLength()-n {
+		panic("DataView.prototype." + m + ": offset is outside the bounds of the DataView")
+	}
+}
+
+// Int8 returns the int8 at offset off.
+func (v DataView) Int8(off int) int8 {
+	return int8(v.call("getInt8", ValueOf(off)).Int())
+}
+
+// Uint8 returns the uint8 at offset off.
+func (v DataView) Uint8(off int) uint8 {
+	return uint8(v.call("getUint8", ValueOf(off)).Int())
+}
+
+// Int16 returns the int16 at offset off.
+func (v DataView) Int16(off int, littleEndian bool) int16 {
+	return int16(v.get("getInt16", off, littleEndian).Int())
+}
+
+// Uint16 returns the uint16 at offset off.
+func (v DataView) Uint16(off int, littleEndian bool) uint16 {
+	return uint16(v.get("getUint16", off, littleEndian).Int())
+}
+
+// Int32 returns the int32 at offset off.
+func (v DataView) Int32(off int, littleEndian bool) int32 {
+	return int32(v.get("getInt32", off, littleEndian).Int())
+}
+
+// Uint32 returns the uint32 at offset off.
+func (v DataView) Uint32(off int, littleEndian bool) uint32 {
+	return uint32(v.get("getUint32", off, littleEndian).Float())
+}
+
+// Int64 returns the int64 at offset off, like getBigInt64.
+func (v DataView) Int64(off int, littleEndian bool) int64 {
+	return int64(v.Uint64(off, littleEndian))
+}
+
+// Uint64 returns the uint64 at offset off, like getBigUint64. It is read as
+// two 32-bit halves, which avoids converting through a bigint.
+func (v DataView) Uint64(off int, littleEndian bool) uint64 {
+	v.checkRange("getBigUint64", off, 8)
+
+	first, second := v.Uint32(off, littleEndian), v.Uint32(off+4, littleEndian)
+	if !littleEndian {
+		first, second = second, first
+	}
+
+	return uint64(second)<<32 | uint64(first)
+}
+
+// Float32 returns the float32 at offset off.
+func (v DataView) Float32(off int, littleEndian bool) float32 {
+	return math.Float32frombits(v.Uint32(off, littleEndian))
+}
+
+// Float64 returns the float64 at offset off.
+func (v DataView) Float64(off int, littleEndian bool) float64 {
+	return v.get("getFloat64", off, littleEndian).Float()
+}
+
+// SetInt8 stores x at offset off.
+func (v DataView) SetInt8(off int, x int8) {
+	v.call("setInt8", ValueOf(off), ValueOf(x))
+}
+
+// SetUint8 stores x at offset off.
+func (v DataView) SetUint8(off int, x uint8) {
+	v.call("setUint8", ValueOf(off), ValueOf(x))
+}
+
+// SetInt16 stores x at offset off.
+func (v DataView) SetInt16(off int, x int16, littleEndian bool) {
+	v.set("setInt16", off, x, littleEndian)
+}
+
+// SetUint16 stores x at offset off.
+func (v DataView) SetUint16(off int, x uint16, littleEndian bool) {
+	v.set("setUint16", off, x, littleEndian)
+}
+
+// SetInt32 stores x at offset off.
+func (v DataView) SetInt32(off int, x int32, littleEndian bool) {
+	v.set("setInt32", off, x, littleEndian)
+}
+
+// SetUint32 stores x at offset off.
+func (v DataView) SetUint32(off int, x uint32, littleEndian bool) {
+	v.set("setUint32", off, x, littleEndian)
+}
+
+// SetInt64 stores x at offset off, like setBigInt64.
+func (v DataView) SetInt64(off int, x int64, littleEndian bool) {
+	v.SetUint64(off, uint64(x), littleEndian)
+}
+
+// SetUint64 stores x at offset off, like setBigUint64. It is written as two
+// 32-bit halves, once the whole range has been checked, so that nothing is
+// written if off is out of range.
+func (v DataView) SetUint64(off int, x uint64, littleEndian bool) {
+	v.checkRange("setBigUint64", off, 8)
+
+	first, second := uint32(x), uint32(x>>32)
+	if !littleEndian {
+		first, second = second, first
+	}
+
+	v.SetUint32(off, first, littleEndian)
+	v.SetUint32(off+4, second, littleEndian)
+}
+
+// SetFloat32 stores x at offset off.
+func (v DataView) SetFloat32(off int, x float32, littleEndian bool) {
+	// keep NaN payloads, which would not survive conversion to float64
+	v.SetUint32(off, math.Float32bits(x), littleEndian)
+}
+
+// SetFloat64 stores x at offset off.
+func (v DataView) SetFloat64(off int, x float64, littleEndian bool) {
+	v.set("setFloat64", off, x, littleEndian)
+}
+
+// ReadAt copies bytes of v from offset off into p, like io.ReaderAt, through
+// a Uint8Array view of the same buffer.
+func (v DataView) ReadAt(p []byte, off int64) (int, error) {
+	return readBufferAt(v.Get("buffer"), v.ByteOffset(), v.ByteLength(), p, off)
+}
+
+// WriteAt copies p into v at offset off, like io.WriterAt, through a
+// Uint8Array view of the same buffer. Bytes past the end of v are dropped and
+// io.ErrShortWrite is returned.
+func (v DataView) WriteAt(p []byte, off int64) (int, error) {
+	return writeBufferAt(v.Buffer(), v.ByteOffset(), v.ByteLength(), p, off, nil)
+}
@@ -0,0 +1,145 @@
+//go:build wasm && js
+
+package gs_test
+
+import (
+	"bytes"
+	"encoding/binary"
+	"io"
+	"math"
+	"testing"
+
+	"github.com/superloach/gs"
+)
+
+func TestDataView(t *testing.T) {
+	b, err := gs.NewArrayBuffer(16)
+	if err != nil {
+		t.Fatalf("new buffer: %v", err)
+	}
+
+	v, err := gs.NewDataView(b, 0, -1)
+	if err != nil {
+		t.Fatalf("new view: %v", err)
+	}
+
+	v.SetInt16(0, -2, true)
+	v.SetUint32(2, 0xdeadbeef, false)
+	v.SetInt64(6, math.MinInt64+5, true)
+	v.SetInt8(14, -1)
+
+	if got := v.Int16(0, true); got != -2 {
+		t.Errorf("Int16: got %d", got)
+	}
+
+	if got := v.Uint32(2, false); got != 0xdeadbeef {
+		t.Errorf("Uint32: got %#x", got)
+	}
+
+	if got := v.Int64(6, true); got != math.MinInt64+5 {
+		t.Errorf("Int64: got %d", got)
+	}
+
+	if got := v.Uint8(14); got != 0xff {
+		t.Errorf("Uint8: got %d", got)
+	}
+
+	// check the layout against encoding/binary
+	p := make([]byte, 16)
+	if _, err := b.ReadAt(p, 0); err != nil {
+		t.Fatalf("ReadAt: %v", err)
+	}
+
+	if binary.BigEndian.Uint32(p[2:]) != 0xdeadbeef || binary.LittleEndian.Uint64(p[6:]) != math.MaxInt64+6 {
+		t.Errorf("layout: got %x", p)
+	}
+
+	js, err := gs.Function{Value: eval(t, "x => new DataView(x).getBigInt64(6, true)")}.Invoke(v.Buffer())
+	if err != nil {
+		t.Fatalf("getBigInt64: %v", err)
+	}
+
+	if got, _ := gs.BigIntOf(js); got.String() != "<bigint: -9223372036854775803>" {
+		t.Errorf("getBigInt64: got %v", got)
+	}
+}
+
+func TestDataViewFloat(t *testing.T) {
+	v, err := gs.NewDataView(eval(t, "new ArrayBuffer(12)"), 0, 12)
+	if err != nil {
+		t.Fatalf("new view: %v", err)
+	}
+
+	v.SetFloat32(0, -1.5, false)
+	v.SetFloat64(4, math.Pi, true)
+
+	if got := v.Float32(0, false); got != -1.5 {
+		t.Errorf("Float32: got %v", got)
+	}
+
+	if got := v.Float64(4, true); got != math.Pi {
+		t.Errorf("Float64: got %v", got)
+	}
+}
+
+func TestDataViewBinary(t *testing.T) {
+	v, err := gs.NewDataView(eval(t, "new ArrayBuffer(8)"), 2, 6)
+	if err != nil {
+		t.Fatalf("new view: %v", err)
+	}
+
+	w := io.NewOffsetWriter(v, 0)
+	if err := binary.Write(w, binary.BigEndian, []uint16{0x0102, 0x0304, 0x0506}); err != nil {
+		t.Fatalf("binary.Write: %v", err)
+	}
+
+	var x uint32
+	if err := binary.Read(io.NewSectionReader(v, 2, 4), binary.BigEndian, &x); err != nil || x != 0x03040506 {
+		t.Errorf("binary.Read: got %#x, %v", x, err)
+	}
+
+	p := make([]byte, 8)
+	n, _ := v.ReadAt(p, 0)
+	if !bytes.Equal(p[:n], []byte{1, 2, 3, 4, 5, 6}) {
+		t.Errorf("ReadAt: got %v", p[:n])
+	}
+
+	if n, err := v.WriteAt([]byte{9, 9}, 5); n != 1 || err == nil {
+		t.Errorf("WriteAt past end: got %d, %v", n, err)
+	}
+
+	b, _ := gs.ArrayBufferOf(v.Buffer())
+	p = make([]byte, 8)
+	b.ReadAt(p, 0)
+	if !bytes.Equal(p, []byte{0, 0, 1, 2, 3, 4, 5, 9}) {
+		t.Errorf("buffer: got %v", p)
+	}
+}
+
+func TestDataViewUint64Range(t *testing.T) {
+	v, err := gs.NewDataView(eval(t, "new ArrayBuffer(8)"), 0, -1)
+	if err != nil {
+		t.Fatalf("new view: %v", err)
+	}
+
+	for _, off := range []int{-2, 1} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("SetUint64 at %d did not panic", off)
+				}
+			}()
+
+			v.SetUint64(off, math.MaxUint64, true)
+		}()
+	}
+
+	if got := v.Uint64(0, true); got != 0 {
+		t.Errorf("out of range SetUint64 wrote %#x", got)
+	}
+
+	var x uint32
+	if err := binary.Read(io.NewSectionReader(v, 0, 4), binary.BigEndian, &x); err != nil || x != 0 {
+		t.Errorf("binary.Read: got %#x, %v", x, err)
+	}
+}
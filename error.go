@@ -0,0 +1,268 @@
+//go:build wasm && js
+
+package gs
+
+import "strconv"
+
+var (
+	ErrorConstructor          = Function{Value: Global.Get("Error")}
+	TypeErrorConstructor      = Function{Value: Global.Get("TypeError")}
+	RangeErrorConstructor     = Function{Value: Global.Get("RangeError")}
+	SyntaxErrorConstructor    = Function{Value: Global.Get("SyntaxError")}
+	ReferenceErrorConstructor = Function{Value: Global.Get("ReferenceError")}
+	AggregateErrorConstructor = Function{Value: Global.Get("AggregateError")}
+	DOMExceptionConstructor   = Function{Value: Global.Get("DOMException")}
+)
+
+// Error wraps a JavaScript error.
+//
+// Errors returned for JavaScript exceptions and rejections are of the more
+// specific types below where they apply, such as TypeError. Those types embed
+// Error, and errors.As with an *Error target matches them too. Thrown values
+// and rejection reasons that are not Error objects are returned as
+// ThrownValue instead.
+type Error struct {
+	// Object is the underlying JavaScript error object.
+	Object
+
+	walk *causeWalk // how e was reached by Unwrap, if it was
+}
+
+// Error implements the error interface. The message is prefixed with the name
+// of e, such as "TypeError: ", if it has one.
+func (e Error) Error() string {
+	msg := e.Get("message").String()
+	if name := e.Name(); name != "" {
+		msg = name + ": " + msg
+	}
+
+	return "JavaScript error: " + msg
+}
+
+// Name returns the name of e, such as "TypeError".
+func (e Error) Name() string {
+	return e.stringProperty("name")
+}
+
+// Message returns the message of e.
+func (e Error) Message() string {
+	return e.stringProperty("message")
+}
+
+// Stack returns the stack trace of e, in the engine's format, or "" if it has
+// none.
+func (e Error) Stack() string {
+	return e.stringProperty("stack")
+}
+
+func (e Error) stringProperty(p string) string {
+	v := e.Get(p)
+	if v.Type() != TypeString {
+		return ""
+	}
+
+	return jsString(v)
+}
+
+// Cause returns the cause of e, as given to the Error constructor. ok is false
+// if e has no cause.
+func (e Error) Cause() (cause Value, ok bool) {
+	// a Proxy that throws is treated as having no cause
+	if has, err := e.HasKey(StringKey("cause")); err != nil || !has {
+		return Undefined.Value, false
+	}
+
+	cause, err := e.GetKey(StringKey("cause"))
+	if err != nil {
+		return Undefined.Value, false
+	}
+
+	return cause, true
+}
+
+// Unwrap returns the Go error for the cause of e, so that errors.Is and
+// errors.As follow chains of causes. It returns nil if e has no cause, or its
+// cause is not an object. A cause that is not an Error object is returned as a
+// ThrownValue.
+//
+// If the chain of causes loops, the causes in the loop are returned until the
+// chain comes back round to one already returned, where Unwrap returns nil so
+// that errors.Is and errors.As end.
+func (e Error) Unwrap() error {
+	cause, ok := e.Cause()
+	if !ok {
+		return nil
+	}
+
+	o, ok := ObjectOf(cause)
+	if !ok {
+		return nil
+	}
+
+	if !isErrorObject(o) {
+		return ThrownValue{Value: cause}
+	}
+
+	w := causeWalk{mark: e.Value, limit: 1}
+	if e.walk != nil {
+		w = *e.walk
+	}
+
+	if o.Equal(w.mark) {
+		return nil
+	}
+
+	if w.steps++; w.steps == w.limit {
+		w = causeWalk{mark: cause, limit: 2 * w.limit}
+	}
+
+	return errorOf(o, &w)
+}
+
+// causeWalk is how far Unwrap has followed a chain of causes, to tell when it
+// loops, with Brent's cycle detection: a loop comes back to mark, which moves
+// on to the latest cause after limit steps, doubling limit each time.
+type causeWalk struct {
+	mark  Value
+	steps int
+	limit int
+}
+
+// As sets target to e if it is an *Error, so that errors.As can find the
+// Error embedded in the more specific error types.
+func (e Error) As(target any) bool {
+	t, ok := target.(*Error)
+	if ok {
+		*t = e
+	}
+
+	return ok
+}
+
+// errorBase lets the specific error types embed Error without a field named
+// Error, which would hide its Error method.
+type errorBase = Error
+
+// TypeError is a JavaScript TypeError, which is also what fetch rejects with
+// on network failures.
+type TypeError struct {
+	errorBase
+}
+
+// RangeError is a JavaScript RangeError.
+type RangeError struct {
+	errorBase
+}
+
+// SyntaxError is a JavaScript SyntaxError.
+type SyntaxError struct {
+	errorBase
+}
+
+// ReferenceError is a JavaScript ReferenceError.
+type ReferenceError struct {
+	errorBase
+}
+
+// AggregateError is a JavaScript AggregateError, as Promise.any rejects with,
+// which holds several errors.
+type AggregateError struct {
+	errorBase
+}
+
+// Errors returns the Go errors for the errors held by e. Values that are not
+// Error objects are returned as ThrownValues.
+func (e AggregateError) Errors() []error {
+	vs := arrayValues(e.Get("errors"))
+
+	errs := make([]error, len(vs))
+	for i, v := range vs {
+		errs[i] = thrownError(v)
+	}
+
+	return errs
+}
+
+// Unwrap returns the errors held by e, followed by the Go error for its cause
+// if it has one, so errors.Is and errors.As look through all of them.
+func (e AggregateError) Unwrap() []error {
+	errs := e.Errors()
+	if cause := e.errorBase.Unwrap(); cause != nil {
+		errs = append(errs, cause)
+	}
+
+	return errs
+}
+
+// DOMException is a DOMException from a web API, such as the AbortError of an
+// aborted fetch. Its Name tells the kinds apart.
+type DOMException struct {
+	errorBase
+}
+
+// Code returns the legacy error code of e, which is 0 for newer names such as
+// "AbortError".
+func (e DOMException) Code() int {
+	return e.Get("code").Int()
+}
+
+// ThrownValue is the error for JavaScript code that throws, or a promise that
+// rejects with, a value that is not an Error object, such as throw "oops",
+// throw undefined or Promise.reject({code: 1}).
+type ThrownValue struct {
+	// Value is the value that was thrown.
+	Value Value
+}
+
+func (e ThrownValue) Error() string {
+	if e.Value.Type() == TypeString {
+		return "JavaScript threw " + strconv.Quote(jsString(e.Value))
+	}
+
+	return "JavaScript threw " + e.Value.String()
+}
+
+// thrownError returns the Go error for the thrown value or rejection reason
+// v: an Error for Error objects, and a ThrownValue otherwise.
+func thrownError(v Value) error {
+	o, ok := ObjectOf(v)
+	if !ok || !isErrorObject(o) {
+		return ThrownValue{Value: v}
+	}
+
+	return errorOf(o, nil)
+}
+
+// isErrorObject reports whether o is an Error object or a DOMException.
+func isErrorObject(o Object) bool {
+	return instanceOfGlobal(o, ErrorConstructor) || instanceOfGlobal(o, DOMExceptionConstructor)
+}
+
+// errorOf returns the Go error for the JavaScript error object o, of the most
+// specific type that applies, reached by Unwrap through walk if it is not nil.
+func errorOf(o Object, walk *causeWalk) error {
+	e := Error{Object: o, walk: walk}
+
+	switch {
+	case instanceOfGlobal(o, AggregateErrorConstructor):
+		return AggregateError{e}
+	case instanceOfGlobal(o, TypeErrorConstructor):
+		return TypeError{e}
+	case instanceOfGlobal(o, RangeErrorConstructor):
+		return RangeError{e}
+	case instanceOfGlobal(o, SyntaxErrorConstructor):
+		return SyntaxError{e}
+	case instanceOfGlobal(o, ReferenceErrorConstructor):
+		return ReferenceError{e}
+	case instanceOfGlobal(o, DOMExceptionConstructor):
+		return DOMException{e}
+	default:
+		return e
+	}
+}
+
+// instanceOfGlobal reports whether o is an instance of the global
+// constructor c, which may be undefined in older engines.
+func instanceOfGlobal(o Object, c Function) bool {
+	return !c.IsUndefined() && o.InstanceOf(c.Value)
+}
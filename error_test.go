@@ -0,0 +1,203 @@
+//go:build wasm && js
+
+package gs_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/superloach/gs"
+)
+
+// throws returns the error from evaluating src.
+func throws(t *testing.T, src string) error {
+	t.Helper()
+
+	_, err := gs.Global.Call("eval", gs.ToString(src))
+	if err == nil {
+		t.Fatalf("eval %q did not throw", src)
+	}
+
+	return err
+}
+
+func TestErrorTypes(t *testing.T) {
+	err := throws(t, "null.x")
+
+	var te gs.TypeError
+	if !errors.As(err, &te) {
+		t.Fatalf("null.x: got %T", err)
+	}
+
+	if te.Name() != "TypeError" || !strings.Contains(te.Stack(), "TypeError") {
+		t.Errorf("Name/Stack: got %q, %q", te.Name(), te.Stack())
+	}
+
+	// the specific types still match a plain Error
+	var e gs.Error
+	if !errors.As(err, &e) || e.Message() != te.Message() {
+		t.Errorf("As Error: got %v", e)
+	}
+
+	var re gs.RangeError
+	if errors.As(err, &re) {
+		t.Error("TypeError matched RangeError")
+	}
+
+	tests := []struct {
+		src  string
+		want any
+	}{
+		{"new Array(-1)", &gs.RangeError{}},
+		{"eval('{')", &gs.SyntaxError{}},
+		{"notDefinedAnywhere", &gs.ReferenceError{}},
+		{"throw new DOMException('stop', 'AbortError')", &gs.DOMException{}},
+		{"throw new Error('plain')", &gs.Error{}},
+	}
+
+	for _, test := range tests {
+		if err := throws(t, test.src); !errors.As(err, test.want) {
+			t.Errorf("%s: got %T", test.src, err)
+		}
+	}
+}
+
+func TestErrorCause(t *testing.T) {
+	err := throws(t, "throw new Error('outer', { cause: new RangeError('inner') })")
+
+	var e gs.Error
+	errors.As(err, &e)
+
+	if cause, ok := e.Cause(); !ok || cause.Type() != gs.TypeObject {
+		t.Errorf("Cause: got %v, %v", cause, ok)
+	}
+
+	var re gs.RangeError
+	if !errors.As(err, &re) || re.Message() != "inner" {
+		t.Errorf("cause chain: got %v", re)
+	}
+
+	plain := gs.Error{Object: gs.Object{Value: eval(t, "new Error('x')")}}
+	if _, ok := plain.Cause(); ok {
+		t.Error("Cause of an error without one")
+	}
+}
+
+func TestErrorCauseCycle(t *testing.T) {
+	for _, src := range []string{
+		"const e = new TypeError('self'); e.cause = e; throw e",
+		"const a = new Error('a'), b = new Error('b'); a.cause = b; b.cause = a; throw a",
+		"const a = new Error('a'), b = new Error('b'); a.cause = b; b.cause = b; throw a",
+	} {
+		err := throws(t, src)
+
+		var re gs.RangeError
+		if errors.As(err, &re) {
+			t.Errorf("%s: found a RangeError", src)
+		}
+
+		if errors.Is(err, io.EOF) {
+			t.Errorf("%s: matched io.EOF", src)
+		}
+	}
+
+	// the causes in a loop are still reached
+	err := throws(t, "const a = new Error('a'), b = new RangeError('b'), c = new Error('c'); a.cause = b; b.cause = c; c.cause = b; throw a")
+
+	var re gs.RangeError
+	if !errors.As(err, &re) || re.Message() != "b" {
+		t.Errorf("RangeError in a loop: got %v", re)
+	}
+
+	var n int
+	for e := err; e != nil && n < 10; e = errors.Unwrap(e) {
+		n++
+	}
+
+	if n >= 10 {
+		t.Error("Unwrap did not end the loop")
+	}
+
+	err = throws(t, "throw new TypeError('bad')")
+	if err.Error() != "JavaScript error: TypeError: bad" {
+		t.Errorf("Error: got %q", err.Error())
+	}
+}
+
+func TestAggregateError(t *testing.T) {
+	_, err := gs.PromiseAny(
+		gs.PromiseReject(errors.New("first")),
+		promise(t, "Promise.reject(new TypeError('second'))"),
+		promise(t, "Promise.reject(3)"),
+	).Await(context.Background())
+
+	var ae gs.AggregateError
+	if !errors.As(err, &ae) {
+		t.Fatalf("Any: got %T", err)
+	}
+
+	if errs := ae.Errors(); len(errs) != 3 {
+		t.Errorf("Errors: got %v", errs)
+	}
+
+	var te gs.TypeError
+	if !errors.As(err, &te) || te.Message() != "second" {
+		t.Errorf("As through AggregateError: got %v", te)
+	}
+
+	var de gs.DOMException
+	if errors.As(err, &de) {
+		t.Error("AggregateError matched DOMException")
+	}
+
+	if err := throws(t, "throw new DOMException('x', 'AbortError')"); !errors.As(err, &de) || de.Name() != "AbortError" || de.Code() != 20 {
+		t.Errorf("DOMException: got %v", err)
+	}
+}
+
+func TestThrownValue(t *testing.T) {
+	tests := []struct {
+		src string
+		msg string
+	}{
+		{`throw "oops"`, `JavaScript threw "oops"`},
+		{"throw 42", "JavaScript threw <number: 42>"},
+		{"throw undefined", "JavaScript threw <undefined>"},
+		{"throw {code: 1}", "JavaScript threw <object>"},
+	}
+
+	for _, test := range tests {
+		err := throws(t, test.src)
+
+		var tv gs.ThrownValue
+		if !errors.As(err, &tv) || err.Error() != test.msg {
+			t.Errorf("%s: got %T %q", test.src, err, err)
+		}
+	}
+
+	thrower := gs.Function{Value: eval(t, "(function (x) { throw x; })")}
+	if _, err := thrower.Invoke(gs.ValueOf(false)); !errors.As(err, &gs.ThrownValue{}) {
+		t.Errorf("Invoke: got %v", err)
+	}
+
+	if _, err := thrower.New(gs.Null.Value); !errors.As(err, &gs.ThrownValue{}) {
+		t.Errorf("New: got %v", err)
+	}
+
+	// a rejection with a value that is not an Error gives the same error
+	_, err := promise(t, "Promise.reject({code: 1})").Await(context.Background())
+	var tv gs.ThrownValue
+	if !errors.As(err, &tv) || tv.Value.Type() != gs.TypeObject {
+		t.Errorf("rejected with object: got %T %v", err, err)
+	}
+
+	// a Go error wrapping a thrown value rethrows the value itself
+	p := gs.PromiseReject(fmt.Errorf("wrapped: %w", gs.ThrownValue{Value: gs.ValueOf(7)}))
+	if _, err := p.Await(context.Background()); !errors.As(err, &gs.ThrownValue{}) || err.(gs.ThrownValue).Value.Int() != 7 {
+		t.Errorf("rethrown: got %v", err)
+	}
+}
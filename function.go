@@ -7,9 +7,11 @@
 package gs
 
 import (
+	"errors"
 	"fmt"
 	"runtime"
 	"sync"
+	"sync/atomic"
 	_ "unsafe"
 )
 
@@ -58,7 +60,7 @@ func FunctionOf(v Valuer) (Function, bool) {
 // is blocked until that function returns. Hence, calling any async JavaScript
 // API, which requires the event loop, like fetch (http.Client), will cause an
 // immediate deadlock. Therefore a blocking function should explicitly start a
-// new goroutine.
+// new goroutine, or be created with WrapAsync.
 //
 // Func.Release must be called to free up resources when the function will not be invoked any more.
 func WrapFunction(fn func(this Value, args []Value) any) (Function, error) {
@@ -88,6 +90,123 @@ func (f Function) Release() {
 	funcsMu.Unlock()
 }
 
+var (
+	helpersOnce sync.Once
+	helpers     Object
+	helpersErr  error
+)
+
+// helpersSource defines the few JavaScript helpers that can't be written as
+// Go callbacks: a wrapper that rethrows values boxed in a Thrown, since a Go
+// function called from JavaScript has no other way to throw, and the methods
+// of Go-backed iterators that need no Go code, which also replace the Go
+// methods once an iterator is done.
+//
+// It is evaluated with the Function constructor the first time a helper is
+// needed, which requires that eval is allowed by any content security policy.
+const helpersSource = `
+	class Thrown {
+		constructor(value) { this.value = value; }
+	}
+	return {
+		Thrown,
+		wrap(fn) {
+			return function () {
+				const res = Reflect.apply(fn, this, arguments);
+				if (res instanceof Thrown) throw res.value;
+				return res;
+			};
+		},
+		self() { return this; },
+		next() { return { value: undefined, done: true }; },
+		return(value) { return { value, done: true }; },
+		throw(e) { throw e; },
+		asyncNext() { return Promise.resolve({ value: undefined, done: true }); },
+		asyncReturn(value) { return Promise.resolve({ value, done: true }); },
+		asyncThrow(e) { return Promise.reject(e); },
+	};
+`
+
+// jsHelpers returns the object defined by helpersSource.
+func jsHelpers() (Object, error) {
+	helpersOnce.Do(func() {
+		factory, err := Function{Value: Global.Get("Function")}.New(ToString(helpersSource))
+		if err != nil {
+			helpersErr = fmt.Errorf("create helpers: %w", err)
+			return
+		}
+
+		res, err := Function{Value: factory}.Invoke()
+		if err != nil {
+			helpersErr = fmt.Errorf("create helpers: %w", err)
+			return
+		}
+
+		helpers = Object{Value: res}
+	})
+
+	return helpers, helpersErr
+}
+
+// wrapFunctionErr is like WrapFunction, but a non-nil error returned by fn is
+// thrown in JavaScript (see jsErrorOf). The returned Function must be
+// released like any other.
+//
+// The error is thrown by a wrapper from helpersSource.
+func wrapFunctionErr(fn func(this Value, args []Value) (any, error)) (Function, error) {
+	h, err := jsHelpers()
+	if err != nil {
+		return Function{}, err
+	}
+
+	inner, err := WrapFunction(func(this Value, args []Value) any {
+		res, err := fn(this, args)
+		if err != nil {
+			thrown, nerr := Function{Value: h.Get("Thrown")}.New(jsErrorOf(err))
+			if nerr != nil {
+				panic("box thrown value: " + nerr.Error())
+			}
+
+			return thrown
+		}
+
+		return res
+	})
+	if err != nil {
+		return Function{}, err
+	}
+
+	outer, err := h.Call("wrap", inner)
+	if err != nil {
+		inner.Release()
+		return Function{}, err
+	}
+
+	return Function{Value: outer, id: inner.id}, nil
+}
+
+// jsErrorOf returns the JavaScript value to throw for err: the underlying
+// object of an Error, the value of a ThrownValue, or a new Error with err's
+// message.
+func jsErrorOf(err error) Value {
+	var jerr Error
+	if errors.As(err, &jerr) {
+		return jerr.Value
+	}
+
+	var tv ThrownValue
+	if errors.As(err, &tv) {
+		return tv.Value
+	}
+
+	e, nerr := ErrorConstructor.New(ToString(err.Error()))
+	if nerr != nil {
+		panic("error construction error: " + nerr.Error())
+	}
+
+	return e
+}
+
 // Invoke does a JavaScript call of the function f with the given arguments.
 // The arguments get mapped to JavaScript values according to the ValueOf function.
 func (f Function) Invoke(args ...Valuer) (Value, error) {
@@ -99,12 +218,7 @@ func (f Function) Invoke(args ...Valuer) (Value, error) {
 	runtime.KeepAlive(argVals)
 
 	if !ok {
-		err, ok := ObjectOf(val)
-		if !ok {
-			panic("non-object error")
-		}
-
-		return Undefined.Value, Error{Object: err}
+		return Undefined.Value, thrownError(val)
 	}
 
 	return val, nil
@@ -123,12 +237,7 @@ func (f Function) New(args ...Valuer) (Value, error) {
 	runtime.KeepAlive(f)
 	runtime.KeepAlive(argVals)
 	if !ok {
-		err, ok := ObjectOf(val)
-		if !ok {
-			panic("non-object error")
-		}
-
-		return Undefined.Value, Error{Object: err}
+		return Undefined.Value, thrownError(val)
 	}
 	return val, nil
 }
@@ -139,7 +248,7 @@ func valueNew(v Ref, args []Ref) (Ref, bool)
 // setEventHandler is defined in the runtime package.
 //
 //go:linkname setEventHandler syscall/js.setEventHandler
-func setEventHandler(fn func())
+func setEventHandler(fn func() bool)
 
 func init() {
 	setEventHandler(handleEvent)
@@ -147,10 +256,23 @@ func init() {
 
 var releasedErrMsg = ToString("call to released function")
 
-func handleEvent() {
+// callbackDepth is the number of Go callbacks called by JavaScript that are
+// running, counting nested ones. The event loop is paused while it is not
+// zero.
+var callbackDepth atomic.Int32
+
+// inCallback reports whether a Go callback called by JavaScript is running,
+// so blocking on the event loop would deadlock.
+func inCallback() bool {
+	return callbackDepth.Load() > 0
+}
+
+// handleEvent calls the function of the pending event, if any.
+// It returns true if an event was handled.
+func handleEvent() bool {
 	cb, ok := ObjectOf(Go.Get("_pendingEvent"))
 	if !ok {
-		return
+		return false
 	}
 	Go.Set("_pendingEvent", Null)
 
@@ -163,7 +285,7 @@ func handleEvent() {
 	funcsMu.Unlock()
 	if !ok {
 		_, _ = Console.Call("error", releasedErrMsg)
-		return
+		return true
 	}
 
 	this := cb.Get("this")
@@ -172,6 +294,98 @@ func handleEvent() {
 	for i := range args {
 		args[i] = argsObj.Index(i)
 	}
+	callbackDepth.Add(1)
+	defer callbackDepth.Add(-1)
+
 	result := f(this, args)
 	cb.Set("result", result)
+	return true
+}
+
+func releaseFunctions(funcs []Function) {
+	for _, f := range funcs {
+		f.Release()
+	}
+}
+
+// cleanups runs the cleanup functions of objects backed by Go functions, such
+// as proxies and iterators, when JavaScript collects the object, so abandoned
+// objects don't hold their Go functions forever. The Go functions must not
+// refer to the object itself, or it is never collected.
+var cleanups cleanupRegistry
+
+type cleanupRegistry struct {
+	once     sync.Once
+	registry Object // the FinalizationRegistry, or undefined if unavailable
+
+	mu     sync.Mutex
+	nextID uint64
+	fns    map[uint64]func()
+}
+
+func (r *cleanupRegistry) init() {
+	r.fns = map[uint64]func(){}
+
+	ctor, ok := FunctionOf(Global.Get("FinalizationRegistry"))
+	if !ok {
+		return
+	}
+
+	cleanup, err := WrapFunction(func(_ Value, args []Value) any {
+		r.run(uint64(args[0].Float()))
+		return nil
+	})
+	if err != nil {
+		return
+	}
+
+	reg, err := ctor.New(cleanup)
+	if err != nil {
+		cleanup.Release()
+		return
+	}
+
+	r.registry = Object{Value: reg}
+}
+
+// register arranges for fn to be called once o is collected, and returns an
+// id to release it earlier with.
+func (r *cleanupRegistry) register(o Object, fn func()) uint64 {
+	r.once.Do(r.init)
+
+	r.mu.Lock()
+	r.nextID++
+	id := r.nextID
+	r.fns[id] = fn
+	r.mu.Unlock()
+
+	if !r.registry.IsUndefined() {
+		// the object doubles as the unregister token
+		_, _ = r.registry.Call("register", o, ValueOf(id), o)
+	}
+
+	return id
+}
+
+// release calls the cleanup function registered as id now, unless it has
+// already been called, and unregisters o.
+func (r *cleanupRegistry) release(o Value, id uint64) {
+	r.once.Do(r.init)
+
+	if _, ok := ObjectOf(o); ok && !r.registry.IsUndefined() {
+		_, _ = r.registry.Call("unregister", o)
+	}
+
+	r.run(id)
+}
+
+func (r *cleanupRegistry) run(id uint64) {
+	r.mu.Lock()
+	fn, ok := r.fns[id]
+	delete(r.fns, id)
+	r.mu.Unlock()
+
+	if ok {
+		fn()
+	}
 }
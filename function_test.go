@@ -0,0 +1,24 @@
+//go:build wasm && js
+
+package gs_test
+
+import (
+	"testing"
+
+	"github.com/superloach/gs"
+)
+
+func TestWrapFunctionCalledFromJS(t *testing.T) {
+	f, err := gs.WrapFunction(func(this gs.Value, args []gs.Value) any {
+		return args[0].Int() + 1
+	})
+	if err != nil {
+		t.Fatalf("WrapFunction: %v", err)
+	}
+	defer f.Release()
+
+	call := gs.Function{Value: eval(t, "f => [1, 2, 3].map(x => f(x)).join()")}
+	if v, err := call.Invoke(f); err != nil || v.String() != "2,3,4" {
+		t.Errorf("map: got %v, %v", v, err)
+	}
+}
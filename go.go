@@ -5,7 +5,27 @@ package gs
 // Go is the instance of the Go class in JavaScript
 var Go = GoType{Object: Object{Value: PredefValue(6, TypeFlagObject)}}
 
-// TODO: implement js Go type
+// GoType is the type of the instance of the Go class from wasm_exec.js that
+// runs this program.
 type GoType struct {
 	Object
 }
+
+// Memory returns the ArrayBuffer holding the linear memory of the program.
+//
+// When the Go heap grows, the memory is replaced by a larger buffer and the
+// old one is detached, along with any views of it. So the buffer is only
+// valid until the next Go allocation; see ViewBytes.
+func (g GoType) Memory() ArrayBuffer {
+	v := g.Value
+	for _, p := range []string{"_inst", "exports", "mem", "buffer"} {
+		v = Object{Value: v}.Get(p)
+	}
+
+	return ArrayBuffer{Object: Object{Value: v}}
+}
+
+// Exited reports whether the program has exited.
+func (g GoType) Exited() bool {
+	return g.Get("exited").Truthy()
+}
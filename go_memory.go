@@ -0,0 +1,61 @@
+//go:build wasm && js
+
+package gs
+
+import "unsafe"
+
+// viewRetries is how many times viewOf tries again when the Go memory grows
+// while the view is being created.
+const viewRetries = 3
+
+// viewOf returns a typed array aliasing the memory of s.
+func viewOf[T any](constructor Function, s []T) TypedArray {
+	if len(s) == 0 {
+		a, err := newTypedArray(constructor, 0)
+		if err != nil {
+			panic("empty view: " + err.Error())
+		}
+
+		return a
+	}
+
+	ptr := ValueOf(uintptr(unsafe.Pointer(&s[0])))
+	length := ValueOf(len(s))
+
+	var err error
+	for i := 0; i < viewRetries; i++ {
+		// passing the arguments may itself allocate and grow the memory,
+		// which detaches the buffer and makes the constructor throw
+		var v Value
+		v, err = constructor.New(Go.Memory(), ptr, length)
+		if err == nil {
+			return TypedArray{Object: Object{Value: v}}
+		}
+	}
+
+	panic("view of Go memory: " + err.Error())
+}
+
+// ViewBytes returns a Uint8Array that aliases the memory of b, without
+// copying. Writes through either one are seen by the other.
+//
+// The view is only valid for a short time, so it suits passing a large buffer
+// to a JavaScript API that uses it synchronously:
+//
+//   - Any Go allocation may grow the memory, which detaches the view, making
+//     it empty. This includes allocations by other goroutines and by calls
+//     into JavaScript, so use the view straight away; Detached reports
+//     whether it is still valid.
+//   - b must be kept alive while the view is in use, such as with
+//     runtime.KeepAlive, or its memory may be reused.
+//   - JavaScript must not keep the view, or anything it was copied into
+//     without copying, after the call.
+func ViewBytes(b []byte) Uint8Array {
+	return Uint8Array{TypedArray: viewOf(Uint8ArrayConstructor, b)}
+}
+
+// View returns a typed array of Ts, such as a Float64Array for float64, that
+// aliases the memory of s, with the lifetime rules of ViewBytes.
+func View[T NumericElement](s []T) NumericArray[T] {
+	return NumericArray[T]{TypedArray: viewOf(numericArrayConstructor[T](), s)}
+}
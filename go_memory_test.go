@@ -0,0 +1,81 @@
+//go:build wasm && js
+
+package gs_test
+
+import (
+	"runtime"
+	"testing"
+
+	"github.com/superloach/gs"
+)
+
+func TestViewBytes(t *testing.T) {
+	b := []byte{1, 2, 3, 4}
+
+	v := gs.ViewBytes(b[1:])
+	if v.Len() != 3 || v.Index(0).Int() != 2 {
+		t.Fatalf("view: got %v", v)
+	}
+
+	if _, err := v.Call("fill", gs.ValueOf(9), gs.ValueOf(1)); err != nil {
+		t.Fatalf("fill: %v", err)
+	}
+
+	if b[0] != 1 || b[1] != 2 || b[2] != 9 || b[3] != 9 {
+		t.Errorf("JS writes: got %v", b)
+	}
+
+	b[1] = 7
+	if v.Index(0).Int() != 7 {
+		t.Error("Go write not seen through the view")
+	}
+
+	runtime.KeepAlive(b)
+
+	if e := gs.ViewBytes(nil); e.Len() != 0 {
+		t.Errorf("empty view: got %d elements", e.Len())
+	}
+}
+
+func TestView(t *testing.T) {
+	s := []float64{0.5, -1}
+
+	v := gs.View(s)
+	v.SetIndex(1, 2.25)
+
+	if s[1] != 2.25 || v.Index(0).Float() != 0.5 {
+		t.Errorf("view: got %v", s)
+	}
+
+	runtime.KeepAlive(s)
+
+	big := []int64{-1 << 40}
+	if b := gs.View(big); b.Len() != 1 || !b.InstanceOf(gs.BigInt64ArrayConstructor.Value) {
+		t.Errorf("int64 view: got %v", b)
+	}
+
+	runtime.KeepAlive(big)
+}
+
+func TestViewDetachedOnGrowth(t *testing.T) {
+	b := make([]byte, 8)
+	v := gs.ViewBytes(b)
+
+	if v.Detached() {
+		t.Fatal("fresh view is detached")
+	}
+
+	size := gs.Go.Memory().ByteLength()
+	big := make([]byte, 2*size)
+	runtime.KeepAlive(big)
+
+	if gs.Go.Memory().ByteLength() <= size {
+		t.Skip("memory did not grow")
+	}
+
+	if !v.Detached() || v.Len() != 0 {
+		t.Errorf("view survived memory growth: detached %v, len %d", v.Detached(), v.Len())
+	}
+
+	runtime.KeepAlive(b)
+}
@@ -0,0 +1,305 @@
+//go:build wasm && js
+
+package gs
+
+import (
+	"fmt"
+	"iter"
+	"sync"
+)
+
+// iterResult returns an iterator result object for v, marshaled to
+// JavaScript.
+func iterResult(v any, done bool) (Value, error) {
+	mv, err := Marshal(v)
+	if err != nil {
+		return Value{}, err
+	}
+
+	return ValueOf(map[string]any{"value": mv, "done": done}), nil
+}
+
+// finishIterator replaces the Go methods of the iterator this with the given
+// helpers, so it keeps working once they are released.
+func finishIterator(this Value, helpers Object, next, ret, throw string) {
+	it, ok := ObjectOf(this)
+	if !ok {
+		return
+	}
+
+	it.Set("next", helpers.Get(next))
+	it.Set("return", helpers.Get(ret))
+	it.Set("throw", helpers.Get(throw))
+}
+
+// iteratorRef returns a function returning the iterator o to its Go methods,
+// which must not keep o alive, so it is held by a WeakRef. Where WeakRef is
+// not available, it falls back to the this value the method was called with.
+func iteratorRef(o Object) func(this Value) Value {
+	self := func(this Value) Value { return this }
+
+	ctor, ok := FunctionOf(Global.Get("WeakRef"))
+	if !ok {
+		return self
+	}
+
+	ref, err := ctor.New(o)
+	if err != nil {
+		return self
+	}
+
+	r := Object{Value: ref}
+	return func(this Value) Value {
+		v, err := r.Call("deref")
+		if err != nil {
+			return this
+		}
+
+		return v
+	}
+}
+
+// NewIterable returns a JavaScript iterator over the values of seq, converted
+// by Marshal, for use with for...of and the spread syntax. Like a generator
+// object, it is its own iterable, so it can only be iterated once. A value
+// that can't be marshaled is thrown as an error from next.
+//
+// The values are pulled from seq as JavaScript calls next, from a function
+// created by WrapFunction, so seq must not block. If JavaScript stops early,
+// with the iterator's return or throw methods, seq's yield returns false.
+// The Go functions are released once the iteration ends, or once JavaScript
+// garbage collects an iterator abandoned part way, where
+// FinalizationRegistry is available.
+func NewIterable(seq iter.Seq[any]) (Object, error) {
+	h, err := jsHelpers()
+	if err != nil {
+		return Object{}, err
+	}
+
+	o, err := ObjectConstructor.New()
+	if err != nil {
+		return Object{}, err
+	}
+
+	it := Object{Value: o}
+	if err := it.SetKey(SymbolKey(SymbolIterator), h.Get("self")); err != nil {
+		return Object{}, err
+	}
+
+	next, stop := iter.Pull(seq)
+
+	var (
+		funcs []Function
+		id    uint64
+	)
+
+	// the methods may be called detached from it, with another this
+	self := iteratorRef(it)
+	finish := func(this Value) {
+		this = self(this)
+		finishIterator(this, h, "next", "return", "throw")
+		cleanups.release(this, id)
+	}
+
+	methods := []struct {
+		name string
+		fn   func(this Value, args []Value) (any, error)
+	}{
+		{"next", func(this Value, _ []Value) (any, error) {
+			v, ok := next()
+			if !ok {
+				finish(this)
+				return iterResult(nil, true)
+			}
+
+			return iterResult(v, false)
+		}},
+		{"return", func(this Value, args []Value) (any, error) {
+			finish(this)
+			return iterResult(argOrUndefined(args), true)
+		}},
+		{"throw", func(this Value, args []Value) (any, error) {
+			finish(this)
+			return nil, ThrownValue{Value: argOrUndefined(args)}
+		}},
+	}
+
+	for _, m := range methods {
+		f, err := wrapFunctionErr(m.fn)
+		if err != nil {
+			stop()
+			releaseFunctions(funcs)
+			return Object{}, fmt.Errorf("wrap %s: %w", m.name, err)
+		}
+
+		funcs = append(funcs, f)
+		it.Set(m.name, f.Value)
+	}
+
+	id = cleanups.register(it, func() {
+		stop()
+		releaseFunctions(funcs)
+	})
+
+	return it, nil
+}
+
+func argOrUndefined(args []Value) Value {
+	if len(args) == 0 {
+		return Undefined.Value
+	}
+
+	return args[0]
+}
+
+// AsyncIterable is a JavaScript async iterator over the values received from
+// a Go channel, as returned by NewAsyncIterable.
+type AsyncIterable struct {
+	Object
+
+	done chan struct{}
+}
+
+// Done returns a channel that is closed once the iteration ends, whether ch
+// was closed or JavaScript stopped early. Producers should stop sending then.
+func (a AsyncIterable) Done() <-chan struct{} {
+	return a.done
+}
+
+// NewAsyncIterable returns a JavaScript async iterator over the values
+// received from ch, converted by Marshal, for use with for await...of. Like an
+// async generator object, it is its own async iterable, so it can only be
+// iterated once. The iteration ends when ch is closed. A value that can't be
+// marshaled rejects the promise returned by next.
+//
+// Each call to next returns a promise, and receives from ch on its own
+// goroutine, so it does not block the event loop; calls are answered in
+// order. If JavaScript stops early, with the iterator's return or throw
+// methods, or garbage collects the iterator where FinalizationRegistry is
+// available, the Done channel is closed, and any further values are received
+// and discarded until ch is closed, so the producer is not left blocked. The
+// Go functions are released once the iteration ends.
+func NewAsyncIterable(ch <-chan any) (AsyncIterable, error) {
+	h, err := jsHelpers()
+	if err != nil {
+		return AsyncIterable{}, err
+	}
+
+	o, err := ObjectConstructor.New()
+	if err != nil {
+		return AsyncIterable{}, err
+	}
+
+	done := make(chan struct{})
+
+	a := AsyncIterable{Object: Object{Value: o}, done: done}
+	if err := a.SetKey(SymbolKey(SymbolAsyncIterator), h.Get("self")); err != nil {
+		return AsyncIterable{}, err
+	}
+
+	var (
+		mu       sync.Mutex
+		last     = make(chan struct{}) // closed when the last call is answered
+		finished bool
+		funcs    []Function
+		id       uint64
+	)
+	close(last)
+
+	// the methods may be called detached from a, with another this
+	self := iteratorRef(a.Object)
+	finish := func(this Value) {
+		this = self(this)
+		finishIterator(this, h, "asyncNext", "asyncReturn", "asyncThrow")
+		cleanups.release(this, id)
+	}
+
+	// enqueue runs fn on a new goroutine once the previous calls have been
+	// answered, and returns a promise settled with its result
+	enqueue := func(fn func() (any, error)) (any, error) {
+		p, resolve, reject, err := newPromise()
+		if err != nil {
+			return nil, err
+		}
+
+		mu.Lock()
+		prev, cur := last, make(chan struct{})
+		last = cur
+		mu.Unlock()
+
+		go func() {
+			defer close(cur)
+			<-prev
+
+			res, err := fn()
+			settlePromise(resolve, reject, res, err)
+		}()
+
+		return p, nil
+	}
+
+	methods := []struct {
+		name string
+		fn   func(this, arg Value) (any, error)
+	}{
+		{"next", func(this, _ Value) (any, error) {
+			mu.Lock()
+			if finished {
+				mu.Unlock()
+				return iterResult(nil, true)
+			}
+			mu.Unlock()
+
+			// only calls queued behind this one can finish the iteration
+			v, ok := <-ch
+			if !ok {
+				finish(this)
+				return iterResult(nil, true)
+			}
+
+			return iterResult(v, false)
+		}},
+		{"return", func(this, arg Value) (any, error) {
+			finish(this)
+			return iterResult(arg, true)
+		}},
+		{"throw", func(this, arg Value) (any, error) {
+			finish(this)
+			return nil, ThrownValue{Value: arg}
+		}},
+	}
+
+	for _, m := range methods {
+		m := m
+		f, err := wrapFunctionErr(func(this Value, args []Value) (any, error) {
+			arg := argOrUndefined(args)
+
+			return enqueue(func() (any, error) {
+				return m.fn(this, arg)
+			})
+		})
+		if err != nil {
+			releaseFunctions(funcs)
+			return AsyncIterable{}, fmt.Errorf("wrap %s: %w", m.name, err)
+		}
+
+		funcs = append(funcs, f)
+		a.Set(m.name, f.Value)
+	}
+
+	id = cleanups.register(a.Object, func() {
+		mu.Lock()
+		finished = true
+		mu.Unlock()
+
+		close(done)
+		releaseFunctions(funcs)
+
+		go func() {
+			for range ch {
+			}
+		}()
+	})
+
+	return a, nil
+}
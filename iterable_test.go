@@ -0,0 +1,177 @@
+//go:build wasm && js
+
+package gs_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/superloach/gs"
+)
+
+func TestNewIterable(t *testing.T) {
+	stopped := false
+	it, err := gs.NewIterable(func(yield func(any) bool) {
+		defer func() { stopped = true }()
+
+		for i := 1; i <= 3; i++ {
+			if !yield(i) {
+				return
+			}
+		}
+	})
+	if err != nil {
+		t.Fatalf("NewIterable: %v", err)
+	}
+
+	sum := gs.Function{Value: eval(t, "it => { let s = 0; for (const x of it) s += x; return s; }")}
+	if v, err := sum.Invoke(it); err != nil || v.Int() != 6 || !stopped {
+		t.Errorf("for...of: got %v, %v (stopped %v)", v, err, stopped)
+	}
+
+	// the iterator keeps reporting done once its functions are released
+	if v, err := it.Call("next"); err != nil || !(gs.Object{Value: v}).Get("done").Truthy() {
+		t.Errorf("next after done: got %v, %v", v, err)
+	}
+}
+
+func TestNewIterableDetached(t *testing.T) {
+	it, err := gs.NewIterable(func(yield func(any) bool) {
+		yield(1)
+	})
+	if err != nil {
+		t.Fatalf("NewIterable: %v", err)
+	}
+
+	// next is called without the iterator as this
+	run := gs.Function{Value: eval(t, `it => {
+		const { next } = it;
+		const got = [next().value, next().done];
+		return [...got, it.next().done].join();
+	}`)}
+	if v, err := run.Invoke(it); err != nil || v.String() != "1,true,true" {
+		t.Errorf("detached next: got %v, %v", v, err)
+	}
+}
+
+func TestNewIterableBreak(t *testing.T) {
+	var yielded int
+	it, err := gs.NewIterable(func(yield func(any) bool) {
+		for {
+			yielded++
+			if !yield("x") {
+				return
+			}
+		}
+	})
+	if err != nil {
+		t.Fatalf("NewIterable: %v", err)
+	}
+
+	first := gs.Function{Value: eval(t, "it => { for (const x of it) return x; }")}
+	if v, err := first.Invoke(it); err != nil || v.String() != "x" {
+		t.Errorf("break: got %v, %v", v, err)
+	}
+
+	if yielded != 1 {
+		t.Errorf("producer ran %d times", yielded)
+	}
+}
+
+func TestNewAsyncIterable(t *testing.T) {
+	ch := make(chan any)
+	a, err := gs.NewAsyncIterable(ch)
+	if err != nil {
+		t.Fatalf("NewAsyncIterable: %v", err)
+	}
+
+	go func() {
+		defer close(ch)
+
+		for i := 0; ; i++ {
+			select {
+			case ch <- i:
+				time.Sleep(time.Millisecond)
+			case <-a.Done():
+				return
+			}
+		}
+	}()
+
+	collect := gs.Function{Value: eval(t, `async it => {
+		const got = [];
+		for await (const x of it) {
+			got.push(x);
+			if (got.length == 3) break;
+		}
+		return got.join();
+	}`)}
+
+	res, err := collect.Invoke(a)
+	if err != nil {
+		t.Fatalf("invoke: %v", err)
+	}
+
+	p, _ := gs.PromiseOf(res)
+	if v, err := p.Await(context.Background()); err != nil || v.String() != "0,1,2" {
+		t.Errorf("for await...of: got %v, %v", v, err)
+	}
+
+	select {
+	case <-a.Done():
+	case <-time.After(time.Second):
+		t.Error("Done not closed after break")
+	}
+}
+
+func TestNewAsyncIterableClosed(t *testing.T) {
+	ch := make(chan any, 2)
+	ch <- "a"
+	ch <- "b"
+	close(ch)
+
+	a, err := gs.NewAsyncIterable(ch)
+	if err != nil {
+		t.Fatalf("NewAsyncIterable: %v", err)
+	}
+
+	vs, err := collect(gs.IterateAsync(context.Background(), a))
+	if err != nil || len(vs) != 2 || vs[0].String()+vs[1].String() != "ab" {
+		t.Errorf("got %v, %v", vs, err)
+	}
+}
+
+func TestNewIterableBadValue(t *testing.T) {
+	it, err := gs.NewIterable(func(yield func(any) bool) {
+		yield(make(chan int))
+	})
+	if err != nil {
+		t.Fatalf("NewIterable: %v", err)
+	}
+
+	if _, err := it.Call("next"); err == nil {
+		t.Error("next: expected an error")
+	}
+}
+
+func TestNewAsyncIterableBadValue(t *testing.T) {
+	ch := make(chan any, 1)
+	ch <- func() {}
+	close(ch)
+
+	a, err := gs.NewAsyncIterable(ch)
+	if err != nil {
+		t.Fatalf("NewAsyncIterable: %v", err)
+	}
+
+	res, err := a.Call("next")
+	if err != nil {
+		t.Fatalf("next: %v", err)
+	}
+
+	p, _ := gs.PromiseOf(res)
+	if _, err := p.Await(context.Background()); err == nil {
+		t.Error("next: expected a rejection")
+	}
+}
@@ -0,0 +1,268 @@
+//go:build wasm && js
+
+package gs
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"iter"
+)
+
+// ErrNotIterable is returned by Iterate and IterateAsync for values without
+// an iterator method.
+var ErrNotIterable = errors.New("value is not iterable")
+
+// iteratorMethod returns v as an object, boxing primitives such as strings,
+// and its method with key sym.
+func iteratorMethod(v Value, sym Symbol) (Object, Function, bool) {
+	o, ok := ObjectOf(v)
+	if !ok {
+		b, err := ObjectConstructor.Invoke(v)
+		if err != nil {
+			return Object{}, Function{}, false
+		}
+
+		o = Object{Value: b}
+	}
+
+	m, err := Reflect.Get(o, SymbolKey(sym))
+	if err != nil {
+		return Object{}, Function{}, false
+	}
+
+	fn, ok := FunctionOf(m)
+	return o, fn, ok
+}
+
+// openIterator calls the iterator method fn on o, and returns the iterator
+// and its next method.
+func openIterator(o Object, fn Function) (it Object, next Function, err error) {
+	res, err := Reflect.Apply(fn, o)
+	if err != nil {
+		return Object{}, Function{}, err
+	}
+
+	it, ok := ObjectOf(res)
+	if !ok {
+		return Object{}, Function{}, fmt.Errorf("iterator %v is not an object", res)
+	}
+
+	m, err := Reflect.Get(it, StringKey("next"))
+	if err != nil {
+		return Object{}, Function{}, err
+	}
+
+	next, ok = FunctionOf(m)
+	if !ok {
+		return Object{}, Function{}, MethodError{Method: "next"}
+	}
+
+	return it, next, nil
+}
+
+// iteratorResult returns the value of an iterator result, and whether the
+// iterator is done.
+func iteratorResult(res Value) (v Value, done bool, err error) {
+	r, ok := ObjectOf(res)
+	if !ok {
+		return Undefined.Value, true, fmt.Errorf("iterator result %v is not an object", res)
+	}
+
+	d, err := Reflect.Get(r, StringKey("done"))
+	if err != nil {
+		return Undefined.Value, true, err
+	}
+
+	if d.Truthy() {
+		return Undefined.Value, true, nil
+	}
+
+	v, err = Reflect.Get(r, StringKey("value"))
+	if err != nil {
+		return Undefined.Value, true, err
+	}
+
+	return v, false, nil
+}
+
+// closeIterator calls the return method of it, if it has one.
+func closeIterator(it Object) (Value, error) {
+	m, err := Reflect.Get(it, StringKey("return"))
+	if err != nil {
+		return Undefined.Value, err
+	}
+
+	ret, ok := FunctionOf(m)
+	if !ok {
+		return Undefined.Value, nil
+	}
+
+	return Reflect.Apply(ret, it)
+}
+
+// Iterate returns a sequence of the values of the iterable v, such as an
+// array, string, Map or generator, from its Symbol.iterator method. Each
+// range over the sequence starts a new iteration.
+//
+// Each value is paired with a nil error. An error from the iterator ends the
+// sequence, paired with an undefined value, such as ErrNotIterable if v has
+// no iterator method.
+//
+// If the loop stops early, or panics, the iterator's return method is called,
+// like a for...of loop in JavaScript. An error from it is dropped, since the
+// loop has already stopped.
+func Iterate(v Valuer) iter.Seq2[Value, error] {
+	return rangeSeq(func(yield func(Value) bool) error {
+		return iterate(v.ValueOf(), yield)
+	})
+}
+
+// rangeSeq returns a sequence of the values run yields, followed by the error
+// that ended it, unless the loop stopped first.
+func rangeSeq(run func(yield func(Value) bool) error) iter.Seq2[Value, error] {
+	return func(yield func(Value, error) bool) {
+		stopped := false
+		err := run(func(v Value) bool {
+			stopped = !yield(v, nil)
+			return !stopped
+		})
+
+		if err != nil && !stopped {
+			yield(Undefined.Value, err)
+		}
+	}
+}
+
+// iterate ranges over the iterable v, and returns the error that ended it.
+func iterate(v Value, yield func(Value) bool) (err error) {
+	o, fn, ok := iteratorMethod(v, SymbolIterator)
+	if !ok {
+		return ErrNotIterable
+	}
+
+	it, next, err := openIterator(o, fn)
+	if err != nil {
+		return err
+	}
+
+	// the iterator is not closed if it fails or finishes by itself
+	closed := false
+	defer func() {
+		if closed {
+			return
+		}
+
+		if _, cerr := closeIterator(it); cerr != nil && err == nil {
+			err = cerr
+		}
+	}()
+
+	for {
+		res, nerr := Reflect.Apply(next, it)
+		if nerr != nil {
+			closed = true
+			return nerr
+		}
+
+		x, done, rerr := iteratorResult(res)
+		if rerr != nil || done {
+			closed = true
+			return rerr
+		}
+
+		if !yield(x) {
+			return nil
+		}
+	}
+}
+
+// IterateAsync is like Iterate, for async iterables such as async generators
+// and streams, from their Symbol.asyncIterator method. Values of iterables
+// without one, but with a Symbol.iterator method, are awaited in turn.
+//
+// The sequence blocks while awaiting each value, so like Promise.Await it
+// ends with ErrAwaitInCallback, before the iterator is opened, if it is
+// ranged over from a Go callback called by JavaScript. If ctx is done,
+// the sequence ends with ctx.Err(), and the iterator's return method is
+// called without waiting for it.
+func IterateAsync(ctx context.Context, v Valuer) iter.Seq2[Value, error] {
+	return rangeSeq(func(yield func(Value) bool) error {
+		return iterateAsync(ctx, v.ValueOf(), yield)
+	})
+}
+
+// iterateAsync ranges over the async iterable v, and returns the error that
+// ended it.
+func iterateAsync(ctx context.Context, v Value, yield func(Value) bool) (err error) {
+	if inCallback() {
+		return ErrAwaitInCallback
+	}
+
+	o, fn, async := iteratorMethod(v, SymbolAsyncIterator)
+	if !async {
+		var ok bool
+		o, fn, ok = iteratorMethod(v, SymbolIterator)
+		if !ok {
+			return ErrNotIterable
+		}
+	}
+
+	it, next, err := openIterator(o, fn)
+	if err != nil {
+		return err
+	}
+
+	closed := false
+	defer func() {
+		if closed {
+			return
+		}
+
+		res, cerr := closeIterator(it)
+		if async && cerr == nil {
+			if ctx.Err() != nil {
+				// don't wait, but don't leave a rejection unhandled
+				_, _ = PromiseResolve(res).Catch(func(error) (any, error) {
+					return nil, nil
+				})
+				return
+			}
+
+			_, cerr = PromiseResolve(res).Await(ctx)
+		}
+
+		if cerr != nil && err == nil {
+			err = cerr
+		}
+	}()
+
+	for {
+		res, nerr := Reflect.Apply(next, it)
+		if nerr == nil && async {
+			res, nerr = PromiseResolve(res).Await(ctx)
+		}
+
+		if nerr != nil {
+			// a cancelled wait leaves the iterator running, so close it
+			closed = ctx.Err() == nil
+			return nerr
+		}
+
+		x, done, rerr := iteratorResult(res)
+		if rerr != nil || done {
+			closed = true
+			return rerr
+		}
+
+		if !async {
+			if x, nerr = PromiseResolve(x).Await(ctx); nerr != nil {
+				return nerr
+			}
+		}
+
+		if !yield(x) {
+			return nil
+		}
+	}
+}
@@ -0,0 +1,179 @@
+//go:build wasm && js
+
+package gs_test
+
+import (
+	"context"
+	"errors"
+	"iter"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/superloach/gs"
+)
+
+// collect returns the values of seq, and the error that ended it.
+func collect(seq iter.Seq2[gs.Value, error]) ([]gs.Value, error) {
+	var vs []gs.Value
+	for v, err := range seq {
+		if err != nil {
+			return vs, err
+		}
+
+		vs = append(vs, v)
+	}
+
+	return vs, nil
+}
+
+func TestIterate(t *testing.T) {
+	var got []string
+
+	for entry, err := range gs.Iterate(eval(t, "new Map([['a', 1], ['b', 2]])")) {
+		if err != nil {
+			t.Fatalf("Map: %v", err)
+		}
+
+		got = append(got, entry.Index(0).String())
+	}
+
+	if strings.Join(got, "") != "ab" {
+		t.Errorf("Map: got %v", got)
+	}
+
+	cs, err := collect(gs.Iterate(gs.ToString("h€y")))
+	if err != nil || len(cs) != 3 || cs[1].String() != "€" {
+		t.Errorf("string: got %v, %v", cs, err)
+	}
+
+	if vs, err := collect(gs.Iterate(gs.ValueOf(1))); len(vs) != 0 || !errors.Is(err, gs.ErrNotIterable) {
+		t.Errorf("number: got %v, %v", vs, err)
+	}
+
+	// a throwing getter is an error, not a crash
+	_, err = collect(gs.Iterate(eval(t, `({
+		[Symbol.iterator]() {
+			return { next() { return { get done() { throw new Error("done"); } }; } };
+		},
+	})`)))
+	if err == nil || !strings.Contains(err.Error(), "done") {
+		t.Errorf("throwing done getter: got %v", err)
+	}
+}
+
+func TestIterateNested(t *testing.T) {
+	// only the first iteration succeeds
+	seq := gs.Iterate(eval(t, `({
+		n: 0,
+		[Symbol.iterator]() {
+			if (this.n++) throw new Error("again");
+			return [1, 2][Symbol.iterator]();
+		},
+	})`))
+
+	n := 0
+	for _, err := range seq {
+		if err != nil {
+			t.Fatalf("outer range: %v", err)
+		}
+
+		n++
+
+		if vs, err := collect(seq); len(vs) != 0 || err == nil {
+			t.Errorf("inner range: got %v, %v", vs, err)
+		}
+	}
+
+	if n != 2 {
+		t.Errorf("outer range: got %d values", n)
+	}
+}
+
+func TestIterateReturn(t *testing.T) {
+	state := eval(t, "({ closed: false })")
+	gen := gs.Function{Value: eval(t, `s => (function* () {
+		try {
+			yield 1; yield 2; yield 3;
+		} finally {
+			s.closed = true;
+		}
+	})()`)}
+
+	g, err := gen.Invoke(state)
+	if err != nil {
+		t.Fatalf("generator: %v", err)
+	}
+
+	for v, err := range gs.Iterate(g) {
+		if err != nil {
+			t.Fatalf("break: %v", err)
+		}
+
+		if v.Int() == 2 {
+			break
+		}
+	}
+
+	if !(gs.Object{Value: state}).Get("closed").Truthy() {
+		t.Error("break: iterator not closed")
+	}
+
+	g = eval(t, "(function* () { yield 1; throw new Error('broken'); })()")
+
+	vs, err := collect(gs.Iterate(g))
+	if len(vs) != 1 || err == nil || !strings.Contains(err.Error(), "broken") {
+		t.Errorf("throw: got %d values, %v", len(vs), err)
+	}
+}
+
+func TestIterateAsync(t *testing.T) {
+	ctx := context.Background()
+	state := eval(t, "({ closed: false })")
+	gen := gs.Function{Value: eval(t, `s => (async function* () {
+		try {
+			for (let i = 0; ; i++) {
+				await new Promise(r => setTimeout(r, 1));
+				yield i;
+			}
+		} finally {
+			s.closed = true;
+		}
+	})()`)}
+
+	g, err := gen.Invoke(state)
+	if err != nil {
+		t.Fatalf("generator: %v", err)
+	}
+
+	var sum int
+	for v, err := range gs.IterateAsync(ctx, g) {
+		if err != nil {
+			t.Fatalf("break: %v", err)
+		}
+
+		sum += v.Int()
+		if v.Int() == 3 {
+			break
+		}
+	}
+
+	if sum != 6 || !(gs.Object{Value: state}).Get("closed").Truthy() {
+		t.Errorf("break: sum %d", sum)
+	}
+
+	// a sync iterable of promises is awaited element by element
+	vs, err := collect(gs.IterateAsync(ctx, eval(t, "[Promise.resolve('x'), 'y']")))
+	if err != nil || len(vs) != 2 || vs[0].String()+vs[1].String() != "xy" {
+		t.Errorf("sync fallback: got %v, %v", vs, err)
+	}
+
+	g, _ = gen.Invoke(eval(t, "({})"))
+
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Millisecond)
+	defer cancel()
+
+	if _, err := collect(gs.IterateAsync(ctx, g)); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("cancelled: got %v", err)
+	}
+}
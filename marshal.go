@@ -0,0 +1,561 @@
+//go:build wasm && js
+
+package gs
+
+import (
+	"math/big"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"unsafe"
+)
+
+// Marshaler is implemented by types that can convert themselves into a
+// JavaScript value.
+type Marshaler interface {
+	MarshalJS() (Value, error)
+}
+
+// An UnsupportedTypeError is returned by Marshal when attempting to convert an
+// unsupported Go type into a JavaScript value.
+type UnsupportedTypeError struct {
+	Type reflect.Type
+}
+
+func (e *UnsupportedTypeError) Error() string {
+	return "gs: unsupported type: " + e.Type.String()
+}
+
+// An UnsupportedValueError is returned by Marshal when attempting to convert
+// an unsupported value, such as one that refers to itself.
+type UnsupportedValueError struct {
+	Value reflect.Value
+	Str   string
+}
+
+func (e *UnsupportedValueError) Error() string {
+	return "gs: unsupported value: " + e.Str
+}
+
+// A MarshalerError is returned by Marshal when a Marshaler returns an error.
+type MarshalerError struct {
+	Type reflect.Type
+	Err  error
+}
+
+func (e *MarshalerError) Error() string {
+	return "gs: error calling MarshalJS for type " + e.Type.String() + ": " + e.Err.Error()
+}
+
+func (e *MarshalerError) Unwrap() error {
+	return e.Err
+}
+
+// Marshal returns x as a JavaScript value. Values accepted by ValueOf are
+// converted the same way, and everything else is walked with reflection:
+//
+//	| Go                     | JavaScript             |
+//	| ---------------------- | ---------------------- |
+//	| Marshaler              | result of MarshalJS    |
+//	| Valuer                 | result of ValueOf      |
+//	| nil pointer/interface  | null                   |
+//	| pointer                | [the pointed-to value] |
+//	| big.Int, *big.Int      | bigint                 |
+//	| []byte                 | new Uint8Array         |
+//	| slices and arrays      | new array              |
+//	| maps                   | new object             |
+//	| structs                | new object             |
+//
+// Nil slices and maps become null. Map keys must be strings or integers.
+//
+// Each exported struct field becomes a property named after the field,
+// unless the field's tag gives another name:
+//
+//	// Field appears as property "myName".
+//	Field int `js:"myName"`
+//
+//	// Field appears as property "myName" and is left out if empty.
+//	Field int `js:"myName,omitempty"`
+//
+//	// Field is ignored.
+//	Field int `js:"-"`
+//
+// The fields of embedded structs are promoted as if they were declared in
+// the outer struct, following the same visibility and precedence rules as
+// encoding/json.
+//
+// Marshal returns an UnsupportedValueError for values that refer to
+// themselves, rather than recursing forever.
+func Marshal(x any) (Value, error) {
+	if x == nil {
+		return Null.Value, nil
+	}
+
+	return marshalValue(reflect.ValueOf(x), &marshalState{})
+}
+
+var (
+	marshalerType = reflect.TypeFor[Marshaler]()
+	valuerType    = reflect.TypeFor[Valuer]()
+	bigIntType    = reflect.TypeOf(big.Int{})
+)
+
+func marshalValue(rv reflect.Value, s *marshalState) (Value, error) {
+	if !rv.IsValid() {
+		return Null.Value, nil
+	}
+
+	t := rv.Type()
+
+	if t.Kind() != reflect.Pointer && rv.CanAddr() &&
+		reflect.PointerTo(t).Implements(marshalerType) {
+		rv = rv.Addr()
+		t = rv.Type()
+	}
+
+	if t.Implements(marshalerType) {
+		if isNilable(t.Kind()) && rv.IsNil() {
+			return Null.Value, nil
+		}
+
+		v, err := rv.Interface().(Marshaler).MarshalJS()
+		if err != nil {
+			return Undefined.Value, &MarshalerError{Type: t, Err: err}
+		}
+
+		return v, nil
+	}
+
+	if t.Implements(valuerType) {
+		if isNilable(t.Kind()) && rv.IsNil() {
+			return Null.Value, nil
+		}
+
+		return rv.Interface().(Valuer).ValueOf(), nil
+	}
+
+	switch t {
+	case bigIntType:
+		x := rv.Interface().(big.Int)
+		return NewBigInt(&x).Value, nil
+	case reflect.PointerTo(bigIntType):
+		if rv.IsNil() {
+			return Null.Value, nil
+		}
+
+		return NewBigInt(rv.Interface().(*big.Int)).Value, nil
+	}
+
+	switch t.Kind() {
+	case reflect.Bool:
+		return ToBoolean(rv.Bool()).Value, nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return FloatValue(float64(rv.Int())), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return FloatValue(float64(rv.Uint())), nil
+	case reflect.Float32, reflect.Float64:
+		return FloatValue(rv.Float()), nil
+	case reflect.String:
+		return MakeValue(stringVal(rv.String())), nil
+	case reflect.Interface:
+		if rv.IsNil() {
+			return Null.Value, nil
+		}
+
+		return marshalValue(rv.Elem(), s)
+	case reflect.Pointer:
+		if rv.IsNil() {
+			return Null.Value, nil
+		}
+
+		leave, err := s.enter(rv)
+		if err != nil {
+			return Undefined.Value, err
+		}
+		defer leave()
+
+		return marshalValue(rv.Elem(), s)
+	case reflect.Slice:
+		if rv.IsNil() {
+			return Null.Value, nil
+		}
+
+		if t.Elem().Kind() == reflect.Uint8 && !reflect.PointerTo(t.Elem()).Implements(marshalerType) {
+			return marshalBytes(rv.Bytes())
+		}
+
+		leave, err := s.enter(rv)
+		if err != nil {
+			return Undefined.Value, err
+		}
+		defer leave()
+
+		return marshalArray(rv, s)
+	case reflect.Array:
+		return marshalArray(rv, s)
+	case reflect.Map:
+		if rv.IsNil() {
+			return Null.Value, nil
+		}
+
+		leave, err := s.enter(rv)
+		if err != nil {
+			return Undefined.Value, err
+		}
+		defer leave()
+
+		return marshalMap(rv, s)
+	case reflect.Struct:
+		return marshalStruct(rv, s)
+	default:
+		return Undefined.Value, &UnsupportedTypeError{Type: t}
+	}
+}
+
+// startDetectingCyclesAfter is how deeply pointers, maps and slices may nest
+// before Marshal starts checking for cycles, which is only worth its cost for
+// unusually deep values.
+const startDetectingCyclesAfter = 1000
+
+// marshalState tracks the pointers, maps and slices that Marshal is inside,
+// so that a cycle is reported as an error rather than overflowing the stack.
+type marshalState struct {
+	ptrLevel uint
+	ptrSeen  map[ptrKey]struct{}
+}
+
+// ptrKey identifies a pointer, map or slice. The type tells a struct from its
+// first field, and the length tells apart slices with the same start.
+type ptrKey struct {
+	ptr unsafe.Pointer
+	typ reflect.Type
+	len int
+}
+
+// enter records that Marshal is inside the pointer, map or slice rv, and
+// returns a function to call once it is done with it. It returns an
+// UnsupportedValueError if rv is already being marshaled.
+func (s *marshalState) enter(rv reflect.Value) (leave func(), err error) {
+	s.ptrLevel++
+	if s.ptrLevel <= startDetectingCyclesAfter {
+		return func() { s.ptrLevel-- }, nil
+	}
+
+	key := ptrKey{ptr: rv.UnsafePointer(), typ: rv.Type()}
+	if rv.Kind() == reflect.Slice {
+		key.len = rv.Len()
+	}
+
+	if s.ptrSeen == nil {
+		s.ptrSeen = map[ptrKey]struct{}{}
+	}
+
+	if _, ok := s.ptrSeen[key]; ok {
+		s.ptrLevel--
+		return nil, &UnsupportedValueError{Value: rv, Str: "encountered a cycle via " + rv.Type().String()}
+	}
+	s.ptrSeen[key] = struct{}{}
+
+	return func() {
+		delete(s.ptrSeen, key)
+		s.ptrLevel--
+	}, nil
+}
+
+func isNilable(k reflect.Kind) bool {
+	switch k {
+	case reflect.Pointer, reflect.Interface, reflect.Map, reflect.Slice, reflect.Func, reflect.Chan:
+		return true
+	default:
+		return false
+	}
+}
+
+func marshalBytes(b []byte) (Value, error) {
+	a, err := NewUint8Array(len(b))
+	if err != nil {
+		return Undefined.Value, err
+	}
+
+	a.CopyBytesToJS(b)
+
+	return a.Value, nil
+}
+
+func marshalArray(rv reflect.Value, s *marshalState) (Value, error) {
+	elems := make([]Valuer, rv.Len())
+	for i := range elems {
+		ev, err := marshalValue(rv.Index(i), s)
+		if err != nil {
+			return Undefined.Value, err
+		}
+
+		elems[i] = ev
+	}
+
+	a, err := NewArray(elems...)
+	if err != nil {
+		return Undefined.Value, err
+	}
+
+	return a.Value, nil
+}
+
+func marshalMap(rv reflect.Value, s *marshalState) (Value, error) {
+	t := rv.Type()
+
+	o, err := ObjectConstructor.New()
+	if err != nil {
+		return Undefined.Value, err
+	}
+
+	iter := rv.MapRange()
+	for iter.Next() {
+		k := iter.Key()
+
+		var name string
+		switch k.Kind() {
+		case reflect.String:
+			name = k.String()
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			name = strconv.FormatInt(k.Int(), 10)
+		case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+			name = strconv.FormatUint(k.Uint(), 10)
+		default:
+			return Undefined.Value, &UnsupportedTypeError{Type: t}
+		}
+
+		ev, err := marshalValue(iter.Value(), s)
+		if err != nil {
+			return Undefined.Value, err
+		}
+
+		o.Set(name, ev)
+	}
+
+	return o, nil
+}
+
+func marshalStruct(rv reflect.Value, s *marshalState) (Value, error) {
+	o, err := ObjectConstructor.New()
+	if err != nil {
+		return Undefined.Value, err
+	}
+
+fields:
+	for _, f := range cachedFields(rv.Type()) {
+		fv := rv
+		for _, i := range f.index {
+			if fv.Kind() == reflect.Pointer {
+				if fv.IsNil() {
+					continue fields
+				}
+				fv = fv.Elem()
+			}
+			fv = fv.Field(i)
+		}
+
+		if f.omitEmpty && isEmptyValue(fv) {
+			continue
+		}
+
+		ev, err := marshalValue(fv, s)
+		if err != nil {
+			return Undefined.Value, err
+		}
+
+		o.Set(f.name, ev)
+	}
+
+	return o, nil
+}
+
+func isEmptyValue(v reflect.Value) bool {
+	switch v.Kind() {
+	case reflect.Array, reflect.Map, reflect.Slice, reflect.String:
+		return v.Len() == 0
+	case reflect.Bool,
+		reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr,
+		reflect.Float32, reflect.Float64,
+		reflect.Interface, reflect.Pointer:
+		return v.IsZero()
+	}
+	return false
+}
+
+// field is a struct field that maps to a JavaScript property.
+type field struct {
+	name      string
+	tagged    bool
+	index     []int
+	typ       reflect.Type
+	omitEmpty bool
+}
+
+var fieldCache sync.Map // map[reflect.Type][]field
+
+func cachedFields(t reflect.Type) []field {
+	if f, ok := fieldCache.Load(t); ok {
+		return f.([]field)
+	}
+
+	f, _ := fieldCache.LoadOrStore(t, typeFields(t))
+	return f.([]field)
+}
+
+// typeFields returns the properties that the struct type t maps to, resolving
+// embedded structs breadth-first with the same dominance rules as
+// encoding/json: a shallower field hides deeper ones of the same name, a
+// tagged field beats untagged ones at the same depth, and names that are
+// still ambiguous are dropped.
+func typeFields(t reflect.Type) []field {
+	current := []field{}
+	next := []field{{typ: t}}
+
+	// the number of times each struct type appears at the current and next
+	// depth, since a type embedded twice makes its fields ambiguous
+	var count, nextCount map[reflect.Type]int
+
+	visited := map[reflect.Type]bool{}
+
+	var fields []field
+
+	for len(next) > 0 {
+		current, next = next, current[:0]
+		count, nextCount = nextCount, map[reflect.Type]int{}
+
+		for _, f := range current {
+			if visited[f.typ] {
+				continue
+			}
+			visited[f.typ] = true
+
+			for i := 0; i < f.typ.NumField(); i++ {
+				sf := f.typ.Field(i)
+
+				if sf.Anonymous {
+					ft := sf.Type
+					if ft.Kind() == reflect.Pointer {
+						ft = ft.Elem()
+					}
+					if !sf.IsExported() && ft.Kind() != reflect.Struct {
+						continue
+					}
+				} else if !sf.IsExported() {
+					continue
+				}
+
+				tag := sf.Tag.Get("js")
+				if tag == "-" {
+					continue
+				}
+
+				name, opts, _ := strings.Cut(tag, ",")
+
+				index := make([]int, len(f.index)+1)
+				copy(index, f.index)
+				index[len(f.index)] = i
+
+				ft := sf.Type
+				if ft.Name() == "" && ft.Kind() == reflect.Pointer {
+					ft = ft.Elem()
+				}
+
+				if name == "" && sf.Anonymous && ft.Kind() == reflect.Struct {
+					nextCount[ft]++
+					if nextCount[ft] == 1 {
+						next = append(next, field{name: ft.Name(), index: index, typ: ft})
+					}
+					continue
+				}
+
+				nf := field{
+					name:      name,
+					tagged:    name != "",
+					index:     index,
+					typ:       ft,
+					omitEmpty: hasOption(opts, "omitempty"),
+				}
+				if nf.name == "" {
+					nf.name = sf.Name
+				}
+
+				fields = append(fields, nf)
+				if count[f.typ] > 1 {
+					// the enclosing type is embedded more than once at this
+					// depth, so add a duplicate for dominantField to drop
+					fields = append(fields, nf)
+				}
+			}
+		}
+	}
+
+	sort.Slice(fields, func(i, j int) bool {
+		x := fields
+		if x[i].name != x[j].name {
+			return x[i].name < x[j].name
+		}
+		if len(x[i].index) != len(x[j].index) {
+			return len(x[i].index) < len(x[j].index)
+		}
+		if x[i].tagged != x[j].tagged {
+			return x[i].tagged
+		}
+		return lessIndex(x[i].index, x[j].index)
+	})
+
+	out := fields[:0]
+	for advance, i := 0, 0; i < len(fields); i += advance {
+		name := fields[i].name
+		for advance = 1; i+advance < len(fields); advance++ {
+			if fields[i+advance].name != name {
+				break
+			}
+		}
+
+		if dominant, ok := dominantField(fields[i : i+advance]); ok {
+			out = append(out, dominant)
+		}
+	}
+	fields = out
+
+	sort.Slice(fields, func(i, j int) bool {
+		return lessIndex(fields[i].index, fields[j].index)
+	})
+
+	return fields
+}
+
+func hasOption(opts, name string) bool {
+	for opts != "" {
+		var opt string
+		opt, opts, _ = strings.Cut(opts, ",")
+		if opt == name {
+			return true
+		}
+	}
+	return false
+}
+
+// dominantField returns the field that wins among fields of the same name,
+// which are sorted by depth and then tagged first. ok is false if the
+// shallowest two are equally good, which hides the name entirely.
+func dominantField(fields []field) (f field, ok bool) {
+	if len(fields) > 1 && len(fields[0].index) == len(fields[1].index) && fields[0].tagged == fields[1].tagged {
+		return field{}, false
+	}
+
+	return fields[0], true
+}
+
+func lessIndex(a, b []int) bool {
+	for i := 0; i < len(a) && i < len(b); i++ {
+		if a[i] != b[i] {
+			return a[i] < b[i]
+		}
+	}
+	return len(a) < len(b)
+}
@@ -0,0 +1,165 @@
+//go:build wasm && js
+
+package gs_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/superloach/gs"
+)
+
+type marshalInner struct {
+	Name string `js:"name"`
+}
+
+type marshalOuter struct {
+	marshalInner
+	ID      int               `js:"id"`
+	Tags    []string          `js:"tags"`
+	Extra   map[string]uint16 `js:"extra,omitempty"`
+	Ignored bool              `js:"-"`
+	Ptr     *float64          `js:"ptr"`
+	private int
+}
+
+type marshalPoint struct{ X, Y int }
+
+func (p marshalPoint) MarshalJS() (gs.Value, error) {
+	return gs.ValueOf([]any{p.X, p.Y}), nil
+}
+
+func stringify(t *testing.T, v gs.Valuer) string {
+	t.Helper()
+
+	json := gs.Object{Value: gs.Global.Get("JSON")}
+	s, err := json.Call("stringify", v)
+	if err != nil {
+		t.Fatalf("stringify: %v", err)
+	}
+
+	return s.String()
+}
+
+func TestMarshal(t *testing.T) {
+	for _, tt := range []struct {
+		name string
+		in   any
+		want string
+	}{
+		{"nil", nil, "null"},
+		{"bool", true, "true"},
+		{"uint8", uint8(7), "7"},
+		{"string", "hi", `"hi"`},
+		{"nil slice", []int(nil), "null"},
+		{"typed slice", []int{1, 2, 3}, "[1,2,3]"},
+		{"array", [2]bool{true, false}, "[true,false]"},
+		{"int map", map[int]string{1: "a"}, `{"1":"a"}`},
+		{"marshaler", marshalPoint{1, 2}, "[1,2]"},
+		{"struct", marshalOuter{
+			marshalInner: marshalInner{Name: "x"},
+			ID:           3,
+			Tags:         []string{"a"},
+			Ignored:      true,
+		}, `{"name":"x","id":3,"tags":["a"],"ptr":null}`},
+		{"struct omitempty", &marshalOuter{Extra: map[string]uint16{"k": 1}}, `{"name":"","id":0,"tags":null,"extra":{"k":1},"ptr":null}`},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			v, err := gs.Marshal(tt.in)
+			if err != nil {
+				t.Fatalf("marshal: %v", err)
+			}
+
+			if got := stringify(t, v); got != tt.want {
+				t.Errorf("got %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMarshalBytes(t *testing.T) {
+	v, err := gs.Marshal([]byte{1, 2, 3})
+	if err != nil {
+		t.Fatalf("marshal: %v", err)
+	}
+
+	a, ok := gs.Uint8ArrayOf(v)
+	if !ok {
+		t.Fatalf("expected Uint8Array, got %v", v)
+	}
+
+	dst := make([]byte, 3)
+	if n := a.CopyBytesToGo(dst); n != 3 || dst[2] != 3 {
+		t.Errorf("got %v (%d bytes)", dst, n)
+	}
+}
+
+func TestMarshalUnsupported(t *testing.T) {
+	_, err := gs.Marshal(make(chan int))
+
+	var ute *gs.UnsupportedTypeError
+	if !errors.As(err, &ute) {
+		t.Fatalf("expected UnsupportedTypeError, got %v", err)
+	}
+}
+
+type (
+	fieldA      struct{ X int }
+	fieldB      struct{ X int }
+	fieldC      struct{ X int }
+	fieldNest   struct{ fieldC }
+	fieldTagged struct {
+		X int `js:"X"`
+	}
+)
+
+func TestMarshalFieldDominance(t *testing.T) {
+	for _, tt := range []struct {
+		name string
+		in   any
+		want string
+	}{
+		// conflicting names at one depth hide deeper fields too
+		{"conflict", struct {
+			fieldA
+			fieldB
+			fieldNest
+		}{fieldNest: fieldNest{fieldC{3}}}, `{}`},
+		{"tagged wins", struct {
+			fieldA
+			fieldTagged
+		}{fieldA{1}, fieldTagged{2}}, `{"X":2}`},
+		{"shallower wins", struct {
+			fieldNest
+			X string
+		}{fieldNest{fieldC{1}}, "top"}, `{"X":"top"}`},
+	} {
+		v, err := gs.Marshal(tt.in)
+		if err != nil {
+			t.Fatalf("%s: %v", tt.name, err)
+		}
+
+		if got := stringify(t, v); got != tt.want {
+			t.Errorf("%s: got %s, want %s", tt.name, got, tt.want)
+		}
+	}
+}
+
+type marshalNode struct {
+	Next *marshalNode
+}
+
+func TestMarshalCycle(t *testing.T) {
+	n := &marshalNode{}
+	n.Next = n
+
+	m := map[string]any{}
+	m["m"] = m
+
+	for _, in := range []any{n, m} {
+		var uve *gs.UnsupportedValueError
+		if _, err := gs.Marshal(in); !errors.As(err, &uve) {
+			t.Errorf("%T: expected UnsupportedValueError, got %v", in, err)
+		}
+	}
+}
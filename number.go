@@ -2,13 +2,164 @@
 
 package gs
 
+import "math"
+
 var _ Valuer = Number{}
 
-// TODO: implement number operations (6.1.6.1)
+var NumberConstructor = Function{Value: Global.Get("Number")}
+
+var numberPrototype = Object{Value: Object{Value: NumberConstructor.Value}.Get("prototype")}
+
+// MaxSafeInteger is the largest integer n such that n and n + 1 are both
+// exactly representable as a Number (Number.MAX_SAFE_INTEGER).
+const MaxSafeInteger = 1<<53 - 1
+
+// Number is a JavaScript number primitive.
+//
+// The arithmetic methods implement the Number operations of the specification
+// (6.1.6.1) in Go, without calling into JavaScript. Note that the js/wasm
+// bridge does not preserve -0, which always arrives as +0.
 type Number struct {
-	Object
+	Value
+}
+
+// NumberOf converts a JavaScript value into a Number, if it is a number.
+func NumberOf(v Valuer) (Number, bool) {
+	vv := v.ValueOf()
+
+	if vv.Type() != TypeNumber {
+		return Number{}, false
+	}
+
+	return Number{
+		Value: vv,
+	}, true
+}
+
+// ToNumber returns f as a JavaScript number.
+func ToNumber(f float64) Number {
+	return Number{Value: FloatValue(f)}
 }
 
 func (n Number) ValueOf() Value {
 	return n.Value
 }
+
+// Exponentiate returns n ** e.
+//
+// Unlike math.Pow, 1 ** NaN and (-1) ** ±Infinity are NaN.
+func (n Number) Exponentiate(e Number) Number {
+	base, exp := n.Float(), e.Float()
+
+	if math.IsNaN(exp) || math.IsInf(exp, 0) && math.Abs(base) == 1 {
+		return ToNumber(math.NaN())
+	}
+
+	return ToNumber(math.Pow(base, exp))
+}
+
+// Remainder returns n % d, which has the sign of n.
+func (n Number) Remainder(d Number) Number {
+	return ToNumber(math.Mod(n.Float(), d.Float()))
+}
+
+// LeftShift returns n << y.
+func (n Number) LeftShift(y Number) Number {
+	return ToNumber(float64(toInt32(n.Float()) << (toUint32(y.Float()) & 31)))
+}
+
+// SignedRightShift returns n >> y.
+func (n Number) SignedRightShift(y Number) Number {
+	return ToNumber(float64(toInt32(n.Float()) >> (toUint32(y.Float()) & 31)))
+}
+
+// UnsignedRightShift returns n >>> y.
+func (n Number) UnsignedRightShift(y Number) Number {
+	return ToNumber(float64(toUint32(n.Float()) >> (toUint32(y.Float()) & 31)))
+}
+
+// SameValue reports whether n and y are the same value, as Object.is does.
+// NaN is the same value as NaN, and +0 is not the same value as -0.
+func (n Number) SameValue(y Number) bool {
+	x, yf := n.Float(), y.Float()
+
+	if math.IsNaN(x) {
+		return math.IsNaN(yf)
+	}
+
+	return x == yf && math.Signbit(x) == math.Signbit(yf)
+}
+
+// SameValueZero reports whether n and y are the same value, treating +0 and
+// -0 as equal, as Array.prototype.includes does.
+func (n Number) SameValueZero(y Number) bool {
+	x, yf := n.Float(), y.Float()
+
+	if math.IsNaN(x) {
+		return math.IsNaN(yf)
+	}
+
+	return x == yf
+}
+
+// IsInteger reports whether n is an integral number, as Number.isInteger does.
+func (n Number) IsInteger() bool {
+	f := n.Float()
+	return !math.IsInf(f, 0) && f == math.Trunc(f)
+}
+
+// IsSafeInteger reports whether n is an integer that can be represented
+// exactly, as Number.isSafeInteger does.
+func (n Number) IsSafeInteger() bool {
+	return n.IsInteger() && math.Abs(n.Float()) <= MaxSafeInteger
+}
+
+// ToFixed formats n using fixed-point notation with the given number of
+// digits after the decimal point, as Number.prototype.toFixed does.
+func (n Number) ToFixed(digits int) (string, error) {
+	return n.format("toFixed", ValueOf(digits))
+}
+
+// ToPrecision formats n to the given number of significant digits, as
+// Number.prototype.toPrecision does.
+func (n Number) ToPrecision(precision int) (string, error) {
+	return n.format("toPrecision", ValueOf(precision))
+}
+
+// ToString formats n in the given radix, which must be between 2 and 36, as
+// Number.prototype.toString does. Formatting is done entirely in Go.
+func (n Number) ToString(radix int) (string, error) {
+	if radix < 2 || radix > 36 {
+		return "", ErrNumberRadix
+	}
+
+	return formatNumber(n.Float(), radix), nil
+}
+
+func (n Number) format(m string, arg Value) (string, error) {
+	s, err := Object{Value: numberPrototype.Get(m)}.Call("call", n, arg)
+	if err != nil {
+		return "", err
+	}
+
+	return jsString(s), nil
+}
+
+// toUint32 implements the abstract operation ToUint32 (7.1.7) for numbers.
+func toUint32(f float64) uint32 {
+	if math.IsNaN(f) || math.IsInf(f, 0) {
+		return 0
+	}
+
+	f = math.Mod(math.Trunc(f), 1<<32)
+	if f < 0 {
+		f += 1 << 32
+	}
+
+	return uint32(f)
+}
+
+// toInt32 implements the abstract operation ToInt32 (7.1.6) for numbers.
+func toInt32(f float64) int32 {
+	return int32(toUint32(f))
+}
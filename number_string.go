@@ -0,0 +1,169 @@
+//go:build wasm && js
+
+package gs
+
+import (
+	"errors"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// ErrNumberRadix is returned when formatting a Number in a radix outside of
+// 2 to 36, where JavaScript would throw a RangeError.
+var ErrNumberRadix = errors.New("gs: toString() radix must be between 2 and 36")
+
+const radixDigits = "0123456789abcdefghijklmnopqrstuvwxyz"
+
+// formatNumber implements Number::toString (6.1.6.1.20) in Go, producing the
+// same output as JavaScript engines do.
+func formatNumber(f float64, radix int) string {
+	switch {
+	case math.IsNaN(f):
+		return "NaN"
+	case f == 0:
+		return "0"
+	case math.IsInf(f, 1):
+		return "Infinity"
+	case math.IsInf(f, -1):
+		return "-Infinity"
+	case f < 0:
+		return "-" + formatNumber(-f, radix)
+	case radix == 10:
+		return formatDecimal(f)
+	default:
+		return formatRadix(f, radix)
+	}
+}
+
+// formatDecimal formats a positive finite f following steps 5 to 12 of
+// Number::toString, with the shortest digits that round-trip.
+func formatDecimal(f float64) string {
+	e := strconv.FormatFloat(f, 'e', -1, 64)
+
+	mant, exp, _ := strings.Cut(e, "e")
+	digits := strings.Replace(mant, ".", "", 1)
+
+	x, _ := strconv.Atoi(exp)
+	n, k := x+1, len(digits)
+
+	var b strings.Builder
+
+	switch {
+	case k <= n && n <= 21:
+		b.WriteString(digits)
+		b.WriteString(strings.Repeat("0", n-k))
+	case 0 < n && n <= 21:
+		b.WriteString(digits[:n])
+		b.WriteByte('.')
+		b.WriteString(digits[n:])
+	case -6 < n && n <= 0:
+		b.WriteString("0.")
+		b.WriteString(strings.Repeat("0", -n))
+		b.WriteString(digits)
+	default:
+		b.WriteByte(digits[0])
+		if k > 1 {
+			b.WriteByte('.')
+			b.WriteString(digits[1:])
+		}
+
+		b.WriteByte('e')
+		if n-1 >= 0 {
+			b.WriteByte('+')
+		}
+		b.WriteString(strconv.Itoa(n - 1))
+	}
+
+	return b.String()
+}
+
+// formatRadix formats a positive finite f in a radix other than 10. The
+// specification leaves this implementation-defined; this follows V8's
+// DoubleToRadixCString, which only emits as many fraction digits as f's
+// precision warrants.
+func formatRadix(f float64, radix int) string {
+	r := float64(radix)
+
+	integer := math.Floor(f)
+	fraction := f - integer
+
+	// only compute fraction digits up to the precision of f
+	delta := math.Max(0.5*(math.Nextafter(f, math.Inf(1))-f), math.SmallestNonzeroFloat64)
+
+	var frac []byte
+	if fraction >= delta {
+		for {
+			fraction *= r
+			delta *= r
+
+			digit := int(fraction)
+			frac = append(frac, radixDigits[digit])
+			fraction -= float64(digit)
+
+			// round half to even, carrying into earlier digits as needed
+			if fraction > 0.5 || fraction == 0.5 && digit&1 == 1 {
+				if fraction+delta > 1 {
+					for {
+						if len(frac) == 0 {
+							integer++
+							break
+						}
+
+						last := strings.IndexByte(radixDigits, frac[len(frac)-1])
+						frac = frac[:len(frac)-1]
+						if last+1 < radix {
+							frac = append(frac, radixDigits[last+1])
+							break
+						}
+					}
+					break
+				}
+			}
+
+			if fraction < delta {
+				break
+			}
+		}
+	}
+
+	var rev []byte
+
+	// digits below the precision of integer are unknown, so write zeros
+	for exponent(integer/r) > 0 {
+		integer /= r
+		rev = append(rev, '0')
+	}
+
+	for {
+		rem := math.Mod(integer, r)
+		rev = append(rev, radixDigits[int(rem)])
+		integer = (integer - rem) / r
+
+		if integer <= 0 {
+			break
+		}
+	}
+
+	b := make([]byte, 0, len(rev)+1+len(frac))
+	for i := len(rev) - 1; i >= 0; i-- {
+		b = append(b, rev[i])
+	}
+
+	if len(frac) > 0 {
+		b = append(b, '.')
+		b = append(b, frac...)
+	}
+
+	return string(b)
+}
+
+// exponent returns e such that f is its 53-bit integer significand times 2**e.
+func exponent(f float64) int {
+	biased := int(math.Float64bits(f)>>52) & 0x7FF
+	if biased == 0 {
+		return -1074
+	}
+
+	return biased - 1075
+}
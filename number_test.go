@@ -0,0 +1,128 @@
+//go:build wasm && js
+
+package gs_test
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+
+	"github.com/superloach/gs"
+)
+
+func TestNumberOperations(t *testing.T) {
+	n := gs.ToNumber
+	nan := math.NaN()
+	inf := math.Inf(1)
+
+	for _, tt := range []struct {
+		name string
+		got  gs.Number
+		want float64
+	}{
+		{"2 ** 10", n(2).Exponentiate(n(10)), 1024},
+		{"1 ** NaN", n(1).Exponentiate(n(nan)), nan},
+		{"-1 ** Infinity", n(-1).Exponentiate(n(inf)), nan},
+		{"NaN ** 0", n(nan).Exponentiate(n(0)), 1},
+		{"-8 ** 0.5", n(-8).Exponentiate(n(0.5)), nan},
+		{"-7 % 2", n(-7).Remainder(n(2)), -1},
+		{"5.5 % 2", n(5.5).Remainder(n(2)), 1.5},
+		{"1 % 0", n(1).Remainder(n(0)), nan},
+		{"3 % Infinity", n(3).Remainder(n(inf)), 3},
+		{"1 << 31", n(1).LeftShift(n(31)), -2147483648},
+		{"1 << 32", n(1).LeftShift(n(32)), 1},
+		{"-9 >> 1", n(-9).SignedRightShift(n(1)), -5},
+		{"-1 >>> 0", n(-1).UnsignedRightShift(n(0)), 4294967295},
+		{"-1 >>> 28", n(-1).UnsignedRightShift(n(28)), 15},
+		{"2**32 + 5 >>> 0", n(1<<32 + 5).UnsignedRightShift(n(0)), 5},
+		{"NaN >>> 0", n(nan).UnsignedRightShift(n(0)), 0},
+	} {
+		got := tt.got.Float()
+		if got != tt.want && !(math.IsNaN(got) && math.IsNaN(tt.want)) {
+			t.Errorf("%s: got %v, want %v", tt.name, got, tt.want)
+		}
+	}
+
+	if !n(nan).SameValue(n(nan)) || !n(nan).SameValueZero(n(nan)) {
+		t.Error("NaN should be the same value as NaN")
+	}
+
+	if n(1).SameValue(n(2)) || !n(0).SameValueZero(n(0)) {
+		t.Error("SameValue/SameValueZero on ordinary numbers")
+	}
+
+	if !n(3).IsInteger() || n(3.5).IsInteger() || n(inf).IsInteger() {
+		t.Error("IsInteger")
+	}
+
+	if !n(gs.MaxSafeInteger).IsSafeInteger() || n(gs.MaxSafeInteger+1).IsSafeInteger() {
+		t.Error("IsSafeInteger")
+	}
+}
+
+func TestNumberFormat(t *testing.T) {
+	n := gs.ToNumber(255.5)
+
+	for _, tt := range []struct {
+		name string
+		fn   func() (string, error)
+		want string
+	}{
+		{"toFixed", func() (string, error) { return n.ToFixed(2) }, "255.50"},
+		{"toPrecision", func() (string, error) { return n.ToPrecision(2) }, "2.6e+2"},
+		{"toString", func() (string, error) { return n.ToString(16) }, "ff.8"},
+	} {
+		got, err := tt.fn()
+		if err != nil {
+			t.Errorf("%s: %v", tt.name, err)
+		} else if got != tt.want {
+			t.Errorf("%s: got %q, want %q", tt.name, got, tt.want)
+		}
+	}
+
+	if _, err := n.ToString(1); err == nil {
+		t.Error("expected RangeError for radix 1")
+	}
+
+	if _, ok := gs.NumberOf(gs.ToString("1")); ok {
+		t.Error("NumberOf should reject strings")
+	}
+}
+
+func TestNumberToString(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+
+	values := []float64{
+		0, 1, -1, 0.1, 0.5, 1.5, 1e21, 1e-6, 1e-7, 123456789012345680000, 1.7976931348623157e308,
+		5e-324, math.NaN(), math.Inf(1), math.Inf(-1), 255, 0.3333333333333333, 4294967295.5,
+		gs.MaxSafeInteger, 1 << 60, -2.5e-10,
+	}
+	for i := 0; i < 200; i++ {
+		values = append(values, math.Float64frombits(rng.Uint64()))
+		values = append(values, rng.Float64()*math.Pow(10, float64(rng.Intn(40)-20)))
+	}
+
+	numberToString := gs.Object{Value: eval(t, "Number.prototype.toString")}
+
+	for _, f := range values {
+		for _, radix := range []int{10, 2, 16, 36, 7} {
+			want, err := numberToString.Call("call", gs.ToNumber(f), gs.ValueOf(radix))
+			if err != nil {
+				t.Fatalf("toString: %v", err)
+			}
+
+			got, err := gs.ToNumber(f).ToString(radix)
+			if err != nil {
+				t.Fatalf("ToString: %v", err)
+			}
+
+			if got != want.String() {
+				t.Errorf("(%v).toString(%d): got %q, want %q", f, radix, got, want.String())
+			}
+		}
+	}
+
+	if s := gs.ValueOf(1e21).String(); s != "<number: 1e+21>" {
+		t.Errorf("String: got %q", s)
+	}
+}
@@ -13,8 +13,6 @@ var ObjectConstructor = Function{Global.Get("Object"), 0}
 
 type Object struct {
 	Value
-
-	methods map[string]Function
 }
 
 func ObjectOf(v Valuer) (Object, bool) {
@@ -39,45 +37,36 @@ func (m MethodError) Error() string {
 
 // Call does a JavaScript call to the method m of object o with the given
 // arguments.
-// It panics if v has no method m.
+// It returns a MethodError if o has no method m.
 // The arguments get mapped to JavaScript values according to the ValueOf
 // function.
 func (o Object) Call(m string, args ...Valuer) (Value, error) {
 	argVals, argRefs := MakeArgs(args)
 
-	if o.methods == nil {
-		o.methods = map[string]Function{}
-	}
-
-	fn, ok := o.methods[m]
-	if !ok {
-		fn, ok = FunctionOf(o.Get(m))
-		if !ok {
-			return Undefined.Value, MethodError{Method: m}
-
-		}
-
-		o.methods[m] = fn
-	}
-
-	res, ok := valueInvoke(fn.Ref, argRefs)
+	res, ok := valueCall(o.Ref, m, argRefs)
 	val := MakeValue(res)
 
 	runtime.KeepAlive(o)
 	runtime.KeepAlive(argVals)
 
 	if !ok {
-		err, ok := ObjectOf(val)
-		if !ok {
-			panic("non-object error")
+		// the method is looked up again without throwing, since a getter or
+		// Proxy trap may be what threw
+		if o.Type().IsObject() {
+			if fn, err := Reflect.Get(o, StringKey(m)); err == nil && fn.Type() != TypeFunction {
+				return Undefined.Value, MethodError{Method: m}
+			}
 		}
 
-		return Undefined.Value, Error{Object: err}
+		return Undefined.Value, thrownError(val)
 	}
 
 	return val, nil
 }
 
+//go:linkname valueCall syscall/js.valueCall
+func valueCall(v Ref, m string, args []Ref) (Ref, bool)
+
 func (o Object) ValueOf() Value {
 	return o.Value
 }
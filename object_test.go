@@ -0,0 +1,44 @@
+//go:build wasm && js
+
+package gs_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/superloach/gs"
+)
+
+func TestObjectCallReceiver(t *testing.T) {
+	o := gs.Object{Value: eval(t, "({ n: 2, twice(x) { return this.n * x; } })")}
+
+	v, err := o.Call("twice", gs.ValueOf(21))
+	if err != nil || v.Int() != 42 {
+		t.Errorf("twice: got %v, %v", v, err)
+	}
+}
+
+func TestObjectCallErrors(t *testing.T) {
+	o := gs.Object{Value: eval(t, "({ n: 1, fail() { throw new Error('failed'); } })")}
+
+	for _, m := range []string{"missing", "n"} {
+		var me gs.MethodError
+		if _, err := o.Call(m); !errors.As(err, &me) || me.Method != m {
+			t.Errorf("%s: got %v", m, err)
+		}
+	}
+
+	var jerr gs.Error
+	if _, err := o.Call("fail"); !errors.As(err, &jerr) {
+		t.Errorf("fail: got %v", err)
+	}
+}
+
+func TestObjectCallThrowingGetter(t *testing.T) {
+	o := gs.Object{Value: eval(t, "new Proxy({}, { get() { throw new Error('trap'); } })")}
+
+	var jerr gs.Error
+	if _, err := o.Call("m"); !errors.As(err, &jerr) || jerr.Message() != "trap" {
+		t.Errorf("got %v", err)
+	}
+}
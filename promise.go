@@ -0,0 +1,229 @@
+//go:build wasm && js
+
+package gs
+
+import (
+	"context"
+	"errors"
+	"fmt"
+)
+
+var PromiseConstructor = Function{Value: Global.Get("Promise")}
+
+// ErrAwaitInCallback is returned by Promise.Await and IterateAsync when they
+// are called from a Go callback called by JavaScript, where waiting on the
+// event loop would deadlock.
+var ErrAwaitInCallback = errors.New("gs: await in a callback called by JavaScript")
+
+// Promise is a JavaScript Promise.
+//
+// The Go callbacks given to Then, Catch and Finally are called like functions
+// created by WrapFunction, so they must not block. They are released once the
+// promise settles; callbacks on a promise that never settles are never
+// released.
+type Promise struct {
+	Object
+}
+
+// PromiseOf converts a JavaScript value into a Promise, if it is one.
+func PromiseOf(v Valuer) (Promise, bool) {
+	o, ok := ObjectOf(v)
+	if !ok || !o.InstanceOf(PromiseConstructor.Value) {
+		return Promise{}, false
+	}
+
+	return Promise{Object: o}, true
+}
+
+func (p Promise) ValueOf() Value {
+	return p.Value
+}
+
+func promiseStatic(m string, args ...Valuer) Promise {
+	res, err := Object{Value: PromiseConstructor.Value}.Call(m, args...)
+	if err != nil {
+		panic("Promise." + m + ": " + err.Error())
+	}
+
+	return Promise{Object: Object{Value: res}}
+}
+
+// PromiseResolve returns a promise resolved with v, like Promise.resolve. If
+// v is a promise or other thenable, the result follows it.
+func PromiseResolve(v Valuer) Promise {
+	return promiseStatic("resolve", v)
+}
+
+// PromiseReject returns a promise rejected with err, like Promise.reject. The
+// reason is the object of an Error, the value of a ThrownValue, or a new Error
+// with err's message.
+func PromiseReject(err error) Promise {
+	return promiseStatic("reject", jsErrorOf(err))
+}
+
+func promiseArray(ps []Valuer) Value {
+	a, err := newArray(ps)
+	if err != nil {
+		panic("array construction error: " + err.Error())
+	}
+
+	return a
+}
+
+// PromiseAll returns a promise fulfilled with an array of the values of ps
+// once they are all fulfilled, or rejected with the first rejection, like
+// Promise.all. Elements of ps that are not promises count as fulfilled.
+func PromiseAll(ps ...Valuer) Promise {
+	return promiseStatic("all", promiseArray(ps))
+}
+
+// PromiseAllSettled returns a promise fulfilled once all of ps are settled,
+// like Promise.allSettled. Its value is an array of objects with a status of
+// "fulfilled" and a value, or "rejected" and a reason.
+func PromiseAllSettled(ps ...Valuer) Promise {
+	return promiseStatic("allSettled", promiseArray(ps))
+}
+
+// PromiseRace returns a promise settled like the first of ps to settle, like
+// Promise.race.
+func PromiseRace(ps ...Valuer) Promise {
+	return promiseStatic("race", promiseArray(ps))
+}
+
+// PromiseAny returns a promise fulfilled like the first of ps to be
+// fulfilled, or rejected with an AggregateError if they are all rejected,
+// like Promise.any.
+func PromiseAny(ps ...Valuer) Promise {
+	return promiseStatic("any", promiseArray(ps))
+}
+
+// then calls p.then with Go callbacks, either of which may be nil, and
+// releases both once one has been called. A nil callback is replaced by one
+// that passes the outcome on, so that one of them is always called.
+func (p Promise) then(onFulfilled, onRejected func(Value) (any, error)) (Promise, error) {
+	if onFulfilled == nil {
+		onFulfilled = func(v Value) (any, error) {
+			return v, nil
+		}
+	}
+
+	if onRejected == nil {
+		onRejected = func(reason Value) (any, error) {
+			return nil, ThrownValue{Value: reason}
+		}
+	}
+
+	var funcs []Function
+	release := func() {
+		releaseFunctions(funcs)
+	}
+
+	args := make([]Valuer, 2)
+	for i, cb := range []func(Value) (any, error){onFulfilled, onRejected} {
+		cb := cb
+		f, err := wrapFunctionErr(func(_ Value, args []Value) (any, error) {
+			defer release()
+			return cb(args[0])
+		})
+		if err != nil {
+			release()
+			return Promise{}, fmt.Errorf("wrap callback: %w", err)
+		}
+
+		funcs = append(funcs, f)
+		args[i] = f
+	}
+
+	res, err := p.Call("then", args...)
+	if err != nil {
+		release()
+		return Promise{}, err
+	}
+
+	return Promise{Object: Object{Value: res}}, nil
+}
+
+// Then returns a promise resolved with the result of onFulfilled once p is
+// fulfilled, or of onRejected once p is rejected, like p.then. Either may be
+// nil, to pass that outcome on unchanged. The callbacks may return a promise
+// to be followed, and a non-nil error rejects the result.
+func (p Promise) Then(onFulfilled func(Value) (any, error), onRejected func(error) (any, error)) (Promise, error) {
+	var rejected func(Value) (any, error)
+	if onRejected != nil {
+		rejected = func(reason Value) (any, error) {
+			return onRejected(thrownError(reason))
+		}
+	}
+
+	return p.then(onFulfilled, rejected)
+}
+
+// Catch is like Then with only onRejected, like p.catch.
+func (p Promise) Catch(onRejected func(error) (any, error)) (Promise, error) {
+	return p.Then(nil, onRejected)
+}
+
+// Finally returns a promise settled like p, after onFinally has been called
+// once p settles, like p.finally. A non-nil error from onFinally rejects the
+// result instead.
+func (p Promise) Finally(onFinally func() error) (Promise, error) {
+	// p.finally calls onFinally exactly once, so it is released then
+	var f Function
+	f, err := wrapFunctionErr(func(_ Value, _ []Value) (any, error) {
+		defer f.Release()
+		return nil, onFinally()
+	})
+	if err != nil {
+		return Promise{}, fmt.Errorf("wrap callback: %w", err)
+	}
+
+	res, err := p.Call("finally", f)
+	if err != nil {
+		f.Release()
+		return Promise{}, err
+	}
+
+	return Promise{Object: Object{Value: res}}, nil
+}
+
+// Await blocks the calling goroutine until p settles, and returns its value
+// or the Go error for its rejection reason. It returns ctx.Err() if ctx is
+// done first.
+//
+// The event loop is paused while a function created by WrapFunction, or any
+// Go callback called by JavaScript, is running, so p could never settle.
+// Await returns ErrAwaitInCallback instead of blocking in one.
+func (p Promise) Await(ctx context.Context) (Value, error) {
+	if inCallback() {
+		return Undefined.Value, ErrAwaitInCallback
+	}
+
+	type result struct {
+		v   Value
+		err error
+	}
+
+	ch := make(chan result, 1)
+
+	_, err := p.then(func(v Value) (any, error) {
+		ch <- result{v: v}
+		return nil, nil
+	}, func(reason Value) (any, error) {
+		ch <- result{err: thrownError(reason)}
+		return nil, nil
+	})
+	if err != nil {
+		return Undefined.Value, err
+	}
+
+	select {
+	case r := <-ch:
+		if r.err != nil {
+			return Undefined.Value, r.err
+		}
+
+		return r.v, nil
+	case <-ctx.Done():
+		return Undefined.Value, ctx.Err()
+	}
+}
@@ -0,0 +1,168 @@
+//go:build wasm && js
+
+package gs_test
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/superloach/gs"
+)
+
+func promise(t *testing.T, src string) gs.Promise {
+	t.Helper()
+
+	p, ok := gs.PromiseOf(eval(t, src))
+	if !ok {
+		t.Fatalf("%s is not a promise", src)
+	}
+
+	return p
+}
+
+func TestPromiseAwait(t *testing.T) {
+	ctx := context.Background()
+
+	v, err := promise(t, "new Promise(r => setTimeout(() => r(42), 1))").Await(ctx)
+	if err != nil || v.Int() != 42 {
+		t.Errorf("fulfilled: got %v, %v", v, err)
+	}
+
+	_, err = promise(t, "Promise.reject(new TypeError('bad'))").Await(ctx)
+	var jerr gs.Error
+	if !errors.As(err, &jerr) || !strings.Contains(err.Error(), "bad") {
+		t.Errorf("rejected with Error: got %v", err)
+	}
+
+	_, err = promise(t, "Promise.reject('oops')").Await(ctx)
+	var tv gs.ThrownValue
+	if !errors.As(err, &tv) || tv.Value.String() != "oops" {
+		t.Errorf("rejected with string: got %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Millisecond)
+	defer cancel()
+
+	if _, err := promise(t, "new Promise(() => {})").Await(ctx); err != context.DeadlineExceeded {
+		t.Errorf("never settled: got %v", err)
+	}
+}
+
+func TestPromiseAwaitInCallback(t *testing.T) {
+	var awaitErr, iterErr error
+	f, err := gs.WrapFunction(func(gs.Value, []gs.Value) any {
+		_, awaitErr = gs.PromiseResolve(gs.ValueOf(1)).Await(context.Background())
+
+		_, iterErr = collect(gs.IterateAsync(context.Background(), eval(t, "[1]")))
+
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("WrapFunction: %v", err)
+	}
+	defer f.Release()
+
+	if _, err := f.Invoke(); err != nil {
+		t.Fatalf("Invoke: %v", err)
+	}
+
+	if !errors.Is(awaitErr, gs.ErrAwaitInCallback) || !errors.Is(iterErr, gs.ErrAwaitInCallback) {
+		t.Errorf("got %v, %v", awaitErr, iterErr)
+	}
+
+	if _, err := gs.PromiseResolve(gs.ValueOf(1)).Await(context.Background()); err != nil {
+		t.Errorf("Await after the callback: %v", err)
+	}
+}
+
+func TestPromiseThen(t *testing.T) {
+	p, err := gs.PromiseResolve(gs.ValueOf(2)).Then(func(v gs.Value) (any, error) {
+		return v.Int() * 10, nil
+	}, nil)
+	if err != nil {
+		t.Fatalf("Then: %v", err)
+	}
+
+	p, err = p.Then(func(v gs.Value) (any, error) {
+		return nil, errors.New("got " + gs.ValueOf(v.Int()).String())
+	}, nil)
+	if err != nil {
+		t.Fatalf("Then: %v", err)
+	}
+
+	var finally bool
+	p, err = p.Finally(func() error {
+		finally = true
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("Finally: %v", err)
+	}
+
+	p, err = p.Catch(func(err error) (any, error) {
+		return "caught: " + err.(gs.Error).Get("message").String(), nil
+	})
+	if err != nil {
+		t.Fatalf("Catch: %v", err)
+	}
+
+	v, err := p.Await(context.Background())
+	if err != nil || v.String() != "caught: got <number: 20>" || !finally {
+		t.Errorf("chain: got %v, %v (finally %v)", v, err, finally)
+	}
+}
+
+func TestPromiseRejectionPassesThrough(t *testing.T) {
+	p, err := gs.PromiseReject(gs.ThrownValue{Value: gs.ValueOf(7)}).Catch(func(err error) (any, error) {
+		return nil, err
+	})
+	if err != nil {
+		t.Fatalf("Catch: %v", err)
+	}
+
+	_, err = p.Await(context.Background())
+	var tv gs.ThrownValue
+	if !errors.As(err, &tv) || tv.Value.Int() != 7 {
+		t.Errorf("rethrown: got %v", err)
+	}
+}
+
+func TestPromiseCombinators(t *testing.T) {
+	ctx := context.Background()
+	slow := func() gs.Promise {
+		return promise(t, "new Promise(r => setTimeout(() => r('slow'), 20))")
+	}
+
+	// create rejected promises as they are used, since node exits on
+	// unhandled rejections
+	failed := func() gs.Promise {
+		return promise(t, "Promise.reject(new Error('failed'))")
+	}
+
+	v, err := gs.PromiseAll(gs.ValueOf(1), gs.PromiseResolve(gs.ValueOf(2)), slow()).Await(ctx)
+	if err != nil || v.Length() != 3 || v.Index(2).String() != "slow" {
+		t.Errorf("All: got %v, %v", v, err)
+	}
+
+	if _, err := gs.PromiseAll(slow(), failed()).Await(ctx); err == nil {
+		t.Error("All with a rejection: got no error")
+	}
+
+	v, err = gs.PromiseAllSettled(slow(), failed()).Await(ctx)
+	if err != nil || (gs.Object{Value: v.Index(1)}).Get("status").String() != "rejected" {
+		t.Errorf("AllSettled: got %v, %v", v, err)
+	}
+
+	v, err = gs.PromiseRace(slow(), gs.ValueOf("now")).Await(ctx)
+	if err != nil || v.String() != "now" {
+		t.Errorf("Race: got %v, %v", v, err)
+	}
+
+	v, err = gs.PromiseAny(failed(), slow()).Await(ctx)
+	if err != nil || v.String() != "slow" {
+		t.Errorf("Any: got %v, %v", v, err)
+	}
+}
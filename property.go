@@ -2,9 +2,173 @@
 
 package gs
 
-// TODO: how to access properties?
-type Property struct {
+import "fmt"
+
+// Descriptor describes a JavaScript property, as used by
+// Object.defineProperty and returned by Object.getOwnPropertyDescriptor.
+//
+// A descriptor with a Get or Set function is an accessor descriptor, and its
+// Value and Writable fields are ignored. Otherwise it is a data descriptor.
+// All fields are always specified, so absent booleans are false and an absent
+// value is undefined.
+type Descriptor struct {
 	Value    Value
-	Writable Boolean
-	Get      Function
+	Writable bool
+
+	Get Function
+	Set Function
+
+	Enumerable   bool
+	Configurable bool
+}
+
+// Property is an own property of an object, as returned by
+// Object.GetOwnPropertyDescriptors.
+type Property struct {
+	Key PropertyKey
+	Descriptor
+}
+
+// Accessor returns an accessor descriptor whose get and set functions run the
+// Go functions get and set, either of which may be nil. The functions are
+// created with WrapFunction, so they are subject to its rules, and must be
+// released with ReleaseAccessor once the property is no longer in use.
+func Accessor(get func(this Value) any, set func(this Value, v Value)) (Descriptor, error) {
+	var d Descriptor
+
+	if get != nil {
+		fn, err := WrapFunction(func(this Value, _ []Value) any {
+			return get(this)
+		})
+		if err != nil {
+			return Descriptor{}, fmt.Errorf("wrap getter: %w", err)
+		}
+
+		d.Get = fn
+	}
+
+	if set != nil {
+		fn, err := WrapFunction(func(this Value, args []Value) any {
+			v := Undefined.Value
+			if len(args) > 0 {
+				v = args[0]
+			}
+
+			set(this, v)
+			return nil
+		})
+		if err != nil {
+			d.Get.Release()
+			return Descriptor{}, fmt.Errorf("wrap setter: %w", err)
+		}
+
+		d.Set = fn
+	}
+
+	return d, nil
+}
+
+// ReleaseAccessor releases the functions of a descriptor created by Accessor.
+func (d Descriptor) ReleaseAccessor() {
+	d.Get.Release()
+	d.Set.Release()
+}
+
+// IsAccessor reports whether d is an accessor descriptor.
+func (d Descriptor) IsAccessor() bool {
+	return !d.Get.IsUndefined() || !d.Set.IsUndefined()
+}
+
+// ValueOf returns d as a JavaScript descriptor object.
+func (d Descriptor) ValueOf() Value {
+	o, err := ObjectConstructor.New()
+	if err != nil {
+		panic("object construction error: " + err.Error())
+	}
+
+	if d.IsAccessor() {
+		o.Set("get", d.Get.Value)
+		o.Set("set", d.Set.Value)
+	} else {
+		o.Set("value", d.Value)
+		o.Set("writable", d.Writable)
+	}
+
+	o.Set("enumerable", d.Enumerable)
+	o.Set("configurable", d.Configurable)
+
+	return o
+}
+
+func descriptorOf(v Value) Descriptor {
+	o := Object{Value: v}
+
+	d := Descriptor{
+		Enumerable:   o.Get("enumerable").Truthy(),
+		Configurable: o.Get("configurable").Truthy(),
+	}
+
+	get, hasGet := FunctionOf(o.Get("get"))
+	set, hasSet := FunctionOf(o.Get("set"))
+
+	if hasGet || hasSet {
+		d.Get, d.Set = get, set
+	} else {
+		d.Value = o.Get("value")
+		d.Writable = o.Get("writable").Truthy()
+	}
+
+	return d
+}
+
+// DefineProperty defines the own property k of object o according to d, like
+// JavaScript's Object.defineProperty.
+func (o Object) DefineProperty(k PropertyKey, d Descriptor) error {
+	_, err := Object{Value: ObjectConstructor.Value}.Call("defineProperty", o, k, d)
+	return err
+}
+
+// GetOwnPropertyDescriptor returns the descriptor of the own property k of
+// object o. ok is false if o has no such own property. An error is returned
+// if JavaScript throws, such as from a Proxy trap.
+func (o Object) GetOwnPropertyDescriptor(k PropertyKey) (d Descriptor, ok bool, err error) {
+	res, err := Object{Value: ObjectConstructor.Value}.Call("getOwnPropertyDescriptor", o, k)
+	if err != nil {
+		return Descriptor{}, false, err
+	}
+
+	if res.IsUndefined() {
+		return Descriptor{}, false, nil
+	}
+
+	return descriptorOf(res), true, nil
+}
+
+// GetOwnPropertyDescriptors returns all own properties of object o, including
+// symbol-keyed ones, in property order. An error is returned if JavaScript
+// throws, such as from a Proxy trap.
+func (o Object) GetOwnPropertyDescriptors() ([]Property, error) {
+	res, err := Object{Value: ObjectConstructor.Value}.Call("getOwnPropertyDescriptors", o)
+	if err != nil {
+		return nil, err
+	}
+
+	descs := Object{Value: res}
+
+	keys, err := Reflect.OwnKeys(descs)
+	if err != nil {
+		return nil, err
+	}
+
+	props := make([]Property, len(keys))
+	for i, k := range keys {
+		d, err := descs.GetKey(k)
+		if err != nil {
+			return nil, err
+		}
+
+		props[i] = Property{Key: k, Descriptor: descriptorOf(d)}
+	}
+
+	return props, nil
 }
@@ -0,0 +1,124 @@
+//go:build wasm && js
+
+package gs
+
+import "strconv"
+
+var _ Valuer = PropertyKey{}
+
+type propertyKeyKind uint8
+
+const (
+	propertyKeyString propertyKeyKind = iota
+	propertyKeyIndex
+	propertyKeySymbol
+)
+
+// PropertyKey is a JavaScript property key, which is either a string or a
+// symbol. Integer keys are strings in JavaScript, but are kept as integers
+// so they can be accessed without formatting them.
+//
+// The zero PropertyKey is the empty string.
+type PropertyKey struct {
+	kind  propertyKeyKind
+	str   string
+	index int
+	sym   Symbol
+}
+
+// StringKey returns the property key for the string s.
+func StringKey(s string) PropertyKey {
+	return PropertyKey{kind: propertyKeyString, str: s}
+}
+
+// IndexKey returns the property key for the integer index i.
+func IndexKey(i int) PropertyKey {
+	return PropertyKey{kind: propertyKeyIndex, index: i}
+}
+
+// SymbolKey returns the property key for the symbol s.
+func SymbolKey(s Symbol) PropertyKey {
+	return PropertyKey{kind: propertyKeySymbol, sym: s}
+}
+
+// PropertyKeyOf converts a JavaScript value into a PropertyKey, if it is a
+// string, a symbol, or a number (which is converted to its string form, as
+// the abstract operation ToPropertyKey does).
+func PropertyKeyOf(v Valuer) (PropertyKey, bool) {
+	vv := v.ValueOf()
+
+	switch vv.Type() {
+	case TypeString:
+		return StringKey(jsString(vv)), true
+	case TypeSymbol:
+		return SymbolKey(Symbol{Value: vv}), true
+	case TypeNumber:
+		n := Number{Value: vv}
+		if n.IsSafeInteger() && n.Float() >= 0 {
+			return IndexKey(int(n.Float())), true
+		}
+
+		return StringKey(formatNumber(n.Float(), 10)), true
+	default:
+		return PropertyKey{}, false
+	}
+}
+
+// ValueOf returns k as a JavaScript string or symbol.
+func (k PropertyKey) ValueOf() Value {
+	switch k.kind {
+	case propertyKeySymbol:
+		return k.sym.Value
+	case propertyKeyIndex:
+		return ToString(strconv.Itoa(k.index)).Value
+	default:
+		return ToString(k.str).Value
+	}
+}
+
+// Symbol returns the symbol k refers to. ok is false if k is a string key.
+func (k PropertyKey) Symbol() (s Symbol, ok bool) {
+	return k.sym, k.kind == propertyKeySymbol
+}
+
+// String returns the string k refers to. For symbol keys, it returns a
+// string of the form "[description]", like JavaScript uses for the names of
+// symbol-keyed functions.
+func (k PropertyKey) String() string {
+	switch k.kind {
+	case propertyKeySymbol:
+		d, _ := k.sym.Description()
+		return "[" + d + "]"
+	case propertyKeyIndex:
+		return strconv.Itoa(k.index)
+	default:
+		return k.str
+	}
+}
+
+// GetKey returns the JavaScript property k of object o. It returns an error
+// if a getter or Proxy trap throws.
+func (o Object) GetKey(k PropertyKey) (Value, error) {
+	return Reflect.Get(o, k)
+}
+
+// SetKey sets the JavaScript property k of object o to ValueOf(x). It
+// returns an error if a setter or Proxy trap throws.
+func (o Object) SetKey(k PropertyKey, x any) error {
+	_, err := Reflect.Set(o, k, x)
+	return err
+}
+
+// HasKey reports whether object o has the property k, either as an own
+// property or through its prototype chain, like JavaScript's in operator. It
+// returns an error if a Proxy trap throws.
+func (o Object) HasKey(k PropertyKey) (bool, error) {
+	return Reflect.Has(o, k)
+}
+
+// DeleteKey deletes the JavaScript property k of object o. It returns an
+// error if a Proxy trap throws.
+func (o Object) DeleteKey(k PropertyKey) error {
+	_, err := Reflect.DeleteProperty(o, k)
+	return err
+}
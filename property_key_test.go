@@ -0,0 +1,64 @@
+//go:build wasm && js
+
+package gs_test
+
+import (
+	"testing"
+
+	"github.com/superloach/gs"
+)
+
+func TestPropertyKey(t *testing.T) {
+	o, _ := gs.ObjectOf(eval(t, "({a: 1, 2: 'two', [Symbol.toStringTag]: 'Thing'})"))
+
+	if got := must(o.GetKey(gs.StringKey("a"))).Int(); got != 1 {
+		t.Errorf("string key: got %d", got)
+	}
+
+	if got := must(o.GetKey(gs.IndexKey(2))).String(); got != "two" {
+		t.Errorf("index key: got %q", got)
+	}
+
+	tag := gs.SymbolKey(gs.SymbolToStringTag)
+	if got := must(o.GetKey(tag)).String(); got != "Thing" {
+		t.Errorf("symbol key: got %q", got)
+	}
+
+	if err := o.SetKey(tag, "Other"); err != nil {
+		t.Fatalf("SetKey: %v", err)
+	}
+	toString := gs.Object{Value: eval(t, "Object.prototype.toString")}
+	if s, _ := toString.Call("call", o); s.String() != "[object Other]" {
+		t.Errorf("toString after SetKey: got %q", s.String())
+	}
+
+	if !must(o.HasKey(tag)) || !must(o.HasKey(gs.StringKey("toString"))) || must(o.HasKey(gs.StringKey("b"))) {
+		t.Error("HasKey")
+	}
+
+	if o.DeleteKey(tag) != nil || o.DeleteKey(gs.IndexKey(2)) != nil || must(o.HasKey(tag)) || must(o.HasKey(gs.IndexKey(2))) {
+		t.Error("DeleteKey")
+	}
+
+	throwing, _ := gs.ObjectOf(eval(t, "new Proxy({}, { get() { throw 1; }, set() { throw 2; }, has() { throw 3; }, deleteProperty() { throw 4; } })"))
+	if _, err := throwing.GetKey(tag); err == nil {
+		t.Error("GetKey: expected an error from the trap")
+	}
+
+	if throwing.SetKey(tag, 1) == nil || throwing.DeleteKey(tag) == nil {
+		t.Error("SetKey/DeleteKey: expected an error from the trap")
+	}
+
+	if _, err := throwing.HasKey(tag); err == nil {
+		t.Error("HasKey: expected an error from the trap")
+	}
+
+	k, ok := gs.PropertyKeyOf(gs.ValueOf(1.5))
+	if !ok || k.String() != "1.5" {
+		t.Errorf("PropertyKeyOf(1.5): got %q, %v", k.String(), ok)
+	}
+
+	if k := gs.SymbolKey(gs.SymbolIterator); k.String() != "[Symbol.iterator]" {
+		t.Errorf("symbol key string: got %q", k.String())
+	}
+}
@@ -0,0 +1,91 @@
+//go:build wasm && js
+
+package gs_test
+
+import (
+	"testing"
+
+	"github.com/superloach/gs"
+)
+
+func TestDefineProperty(t *testing.T) {
+	o, _ := gs.ObjectOf(eval(t, "({})"))
+
+	err := o.DefineProperty(gs.StringKey("fixed"), gs.Descriptor{
+		Value:      gs.ValueOf(1),
+		Enumerable: true,
+	})
+	if err != nil {
+		t.Fatalf("define data property: %v", err)
+	}
+
+	count := 0
+	acc, err := gs.Accessor(
+		func(this gs.Value) any { return count },
+		func(this gs.Value, v gs.Value) { count = v.Int() },
+	)
+	if err != nil {
+		t.Fatalf("accessor: %v", err)
+	}
+	defer acc.ReleaseAccessor()
+
+	acc.Configurable = true
+	if err := o.DefineProperty(gs.SymbolKey(gs.SymbolToStringTag), acc); err != nil {
+		t.Fatalf("define accessor: %v", err)
+	}
+
+	count = 5
+	if got := must(o.GetKey(gs.SymbolKey(gs.SymbolToStringTag))).Int(); got != 5 {
+		t.Errorf("getter: got %d", got)
+	}
+
+	if err := o.SetKey(gs.SymbolKey(gs.SymbolToStringTag), 7); err != nil || count != 7 {
+		t.Errorf("setter: got %d", count)
+	}
+
+	d, ok, err := o.GetOwnPropertyDescriptor(gs.StringKey("fixed"))
+	if err != nil || !ok || d.IsAccessor() || d.Value.Int() != 1 || d.Writable || !d.Enumerable || d.Configurable {
+		t.Errorf("data descriptor: got %+v, %v, %v", d, ok, err)
+	}
+
+	if _, ok, err := o.GetOwnPropertyDescriptor(gs.StringKey("missing")); ok || err != nil {
+		t.Errorf("missing property: got %v, %v", ok, err)
+	}
+
+	// redefining a non-configurable property throws
+	if err := o.DefineProperty(gs.StringKey("fixed"), gs.Descriptor{Value: gs.ValueOf(2)}); err == nil {
+		t.Error("expected TypeError redefining fixed")
+	}
+
+	props, err := o.GetOwnPropertyDescriptors()
+	if err != nil {
+		t.Fatalf("GetOwnPropertyDescriptors: %v", err)
+	}
+
+	if len(props) != 2 {
+		t.Fatalf("expected 2 properties, got %d", len(props))
+	}
+
+	if props[0].Key.String() != "fixed" || !props[1].IsAccessor() || !props[1].Configurable {
+		t.Errorf("got %+v", props)
+	}
+
+	if _, ok := props[1].Key.Symbol(); !ok {
+		t.Error("expected symbol key")
+	}
+}
+
+func TestPropertyDescriptorTrapThrows(t *testing.T) {
+	o := gs.Object{Value: eval(t, `new Proxy({ a: 1 }, {
+		getOwnPropertyDescriptor() { throw new Error("no descriptors"); },
+		ownKeys() { throw new Error("no keys"); },
+	})`)}
+
+	if _, _, err := o.GetOwnPropertyDescriptor(gs.StringKey("a")); err == nil {
+		t.Error("GetOwnPropertyDescriptor: expected the trap's error")
+	}
+
+	if _, err := o.GetOwnPropertyDescriptors(); err == nil {
+		t.Error("GetOwnPropertyDescriptors: expected the trap's error")
+	}
+}
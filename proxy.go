@@ -0,0 +1,180 @@
+//go:build wasm && js
+
+package gs
+
+import "fmt"
+
+var ProxyConstructor = Function{Value: Global.Get("Proxy")}
+
+// ProxyHandler handles the operations on a Proxy. It may implement any of the
+// ProxyGetTrap, ProxySetTrap, ProxyHasTrap, ProxyDeletePropertyTrap,
+// ProxyOwnKeysTrap, ProxyApplyTrap and ProxyConstructTrap interfaces;
+// operations without a trap are forwarded to the target.
+//
+// Traps are called like functions created by WrapFunction, so they must not
+// block. An error returned by a trap is thrown in JavaScript.
+type ProxyHandler any
+
+// ProxyGetTrap handles reading a property of a Proxy.
+type ProxyGetTrap interface {
+	Get(target Object, key PropertyKey, receiver Value) (Value, error)
+}
+
+// ProxySetTrap handles writing a property of a Proxy. It reports whether the
+// property was set.
+type ProxySetTrap interface {
+	Set(target Object, key PropertyKey, value, receiver Value) (bool, error)
+}
+
+// ProxyHasTrap handles the in operator on a Proxy.
+type ProxyHasTrap interface {
+	Has(target Object, key PropertyKey) (bool, error)
+}
+
+// ProxyDeletePropertyTrap handles deleting a property of a Proxy. It reports
+// whether the property was deleted.
+type ProxyDeletePropertyTrap interface {
+	DeleteProperty(target Object, key PropertyKey) (bool, error)
+}
+
+// ProxyOwnKeysTrap handles listing the own property keys of a Proxy.
+type ProxyOwnKeysTrap interface {
+	OwnKeys(target Object) ([]PropertyKey, error)
+}
+
+// ProxyApplyTrap handles calling a Proxy. The target must be a function.
+type ProxyApplyTrap interface {
+	Apply(target Function, this Value, args []Value) (Value, error)
+}
+
+// ProxyConstructTrap handles calling a Proxy with new. The target must be a
+// constructor.
+type ProxyConstructTrap interface {
+	Construct(target Function, args []Value, newTarget Function) (Object, error)
+}
+
+// Proxy is a JavaScript Proxy object whose traps are implemented in Go.
+type Proxy struct {
+	Object
+
+	id uint64
+}
+
+// NewProxy returns a new Proxy for target whose operations are handled by
+// handler.
+//
+// The trap functions are released once JavaScript garbage collects the
+// proxy, where FinalizationRegistry is available, or when Release is called.
+func NewProxy(target Object, handler ProxyHandler) (Proxy, error) {
+	h, err := ObjectConstructor.New()
+	if err != nil {
+		return Proxy{}, err
+	}
+
+	var funcs []Function
+	trap := func(name string, fn func(args []Value) (any, error)) error {
+		f, err := wrapFunctionErr(func(_ Value, args []Value) (any, error) {
+			return fn(args)
+		})
+		if err != nil {
+			return fmt.Errorf("wrap %s trap: %w", name, err)
+		}
+
+		funcs = append(funcs, f)
+		h.Set(name, f.Value)
+		return nil
+	}
+
+	if t, ok := handler.(ProxyGetTrap); ok {
+		err = trap("get", func(args []Value) (any, error) {
+			return t.Get(Object{Value: args[0]}, trapKey(args[1]), args[2])
+		})
+	}
+
+	if t, ok := handler.(ProxySetTrap); ok && err == nil {
+		err = trap("set", func(args []Value) (any, error) {
+			return t.Set(Object{Value: args[0]}, trapKey(args[1]), args[2], args[3])
+		})
+	}
+
+	if t, ok := handler.(ProxyHasTrap); ok && err == nil {
+		err = trap("has", func(args []Value) (any, error) {
+			return t.Has(Object{Value: args[0]}, trapKey(args[1]))
+		})
+	}
+
+	if t, ok := handler.(ProxyDeletePropertyTrap); ok && err == nil {
+		err = trap("deleteProperty", func(args []Value) (any, error) {
+			return t.DeleteProperty(Object{Value: args[0]}, trapKey(args[1]))
+		})
+	}
+
+	if t, ok := handler.(ProxyOwnKeysTrap); ok && err == nil {
+		err = trap("ownKeys", func(args []Value) (any, error) {
+			keys, err := t.OwnKeys(Object{Value: args[0]})
+			if err != nil {
+				return nil, err
+			}
+
+			vs := make([]Valuer, len(keys))
+			for i, k := range keys {
+				vs[i] = k
+			}
+
+			return newArray(vs)
+		})
+	}
+
+	if t, ok := handler.(ProxyApplyTrap); ok && err == nil {
+		err = trap("apply", func(args []Value) (any, error) {
+			return t.Apply(Function{Value: args[0]}, args[1], arrayValues(args[2]))
+		})
+	}
+
+	if t, ok := handler.(ProxyConstructTrap); ok && err == nil {
+		err = trap("construct", func(args []Value) (any, error) {
+			return t.Construct(Function{Value: args[0]}, arrayValues(args[1]), Function{Value: args[2]})
+		})
+	}
+
+	if err != nil {
+		releaseFunctions(funcs)
+		return Proxy{}, err
+	}
+
+	p, err := ProxyConstructor.New(target, h)
+	if err != nil {
+		releaseFunctions(funcs)
+		return Proxy{}, err
+	}
+
+	proxy := Proxy{Object: Object{Value: p}}
+	proxy.id = cleanups.register(proxy.Object, func() { releaseFunctions(funcs) })
+
+	return proxy, nil
+}
+
+// Release releases the trap functions of p. The proxy must not be used after
+// calling Release.
+func (p Proxy) Release() {
+	cleanups.release(p.Value, p.id)
+}
+
+func trapKey(v Value) PropertyKey {
+	k, ok := PropertyKeyOf(v)
+	if !ok {
+		panic("proxy trap called with a non-key")
+	}
+
+	return k
+}
+
+// arrayValues returns the elements of the JavaScript array a.
+func arrayValues(a Value) []Value {
+	vs := make([]Value, a.Length())
+	for i := range vs {
+		vs[i] = a.Index(i)
+	}
+
+	return vs
+}
@@ -0,0 +1,102 @@
+//go:build wasm && js
+
+package gs_test
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/superloach/gs"
+)
+
+// lazySquares is a proxy handler exposing squares of integer keys.
+type lazySquares struct {
+	gets int
+}
+
+func (h *lazySquares) Get(target gs.Object, key gs.PropertyKey, receiver gs.Value) (gs.Value, error) {
+	if key.String() == "boom" {
+		return gs.Value{}, errors.New("no boom allowed")
+	}
+
+	h.gets++
+	n, err := strconv.Atoi(key.String())
+	if err != nil {
+		return gs.Undefined.Value, nil
+	}
+
+	return gs.ValueOf(n * n), nil
+}
+
+func (h *lazySquares) Has(target gs.Object, key gs.PropertyKey) (bool, error) {
+	return key.String() != "missing", nil
+}
+
+func (h *lazySquares) OwnKeys(target gs.Object) ([]gs.PropertyKey, error) {
+	return []gs.PropertyKey{gs.StringKey("1"), gs.StringKey("2")}, nil
+}
+
+func TestProxy(t *testing.T) {
+	h := &lazySquares{}
+
+	target, _ := gs.ObjectOf(eval(t, "({})"))
+	p, err := gs.NewProxy(target, h)
+	if err != nil {
+		t.Fatalf("new proxy: %v", err)
+	}
+	defer p.Release()
+
+	if got := p.Get("12").Int(); got != 144 {
+		t.Errorf("get: got %d", got)
+	}
+
+	if h.gets != 1 {
+		t.Errorf("expected 1 get, got %d", h.gets)
+	}
+
+	if !must(p.HasKey(gs.StringKey("x"))) || must(p.HasKey(gs.StringKey("missing"))) {
+		t.Error("has trap")
+	}
+
+	keys, err := gs.Reflect.OwnKeys(p.Object)
+	if err != nil || len(keys) != 2 || keys[1].String() != "2" {
+		t.Errorf("ownKeys: got %v, %v", keys, err)
+	}
+
+	_, err = gs.Reflect.Get(p.Object, gs.StringKey("boom"))
+	if err == nil || !strings.Contains(err.Error(), "no boom allowed") {
+		t.Errorf("expected thrown error, got %v", err)
+	}
+}
+
+type doubler struct{}
+
+func (doubler) Apply(target gs.Function, this gs.Value, args []gs.Value) (gs.Value, error) {
+	v, err := target.Invoke(args[0])
+	if err != nil {
+		return gs.Value{}, err
+	}
+
+	return gs.ValueOf(v.Float() * 2), nil
+}
+
+func TestProxyApply(t *testing.T) {
+	inc, _ := gs.ObjectOf(eval(t, "(x => x + 1)"))
+
+	p, err := gs.NewProxy(inc, doubler{})
+	if err != nil {
+		t.Fatalf("new proxy: %v", err)
+	}
+	defer p.Release()
+
+	fn, ok := gs.FunctionOf(p)
+	if !ok {
+		t.Fatal("proxy of a function is not a function")
+	}
+
+	if v, err := fn.Invoke(gs.ValueOf(4)); err != nil || v.Int() != 10 {
+		t.Errorf("apply: got %v, %v", v, err)
+	}
+}
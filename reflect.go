@@ -1,3 +1,5 @@
+//go:build wasm && js
+
 package gs
 
 var Reflect = ReflectType{
@@ -6,30 +8,178 @@ var Reflect = ReflectType{
 	},
 }
 
-// TODO: finish Reflect methods and docs
+// ReflectType is the type of the Reflect namespace object, which provides the
+// internal methods of JavaScript objects as functions.
+//
+// Reflect functions throw a TypeError when given a primitive where an object
+// is expected, which the methods return as an Error.
 type ReflectType struct {
 	Object
 }
 
-func (r ReflectType) Constructor(v Valuer) Object {
-	con, err := r.Call("constructor", v.ValueOf())
+// newArray returns a new JavaScript array containing vs.
+func newArray(vs []Valuer) (Value, error) {
+	a, err := ArrayConstructor.New(FloatValue(float64(len(vs))))
+	if err != nil {
+		return Undefined.Value, err
+	}
+
+	for i, v := range vs {
+		a.SetIndex(i, v.ValueOf())
+	}
+
+	return a, nil
+}
+
+// Apply calls target with this as the value of this and the given arguments,
+// like Reflect.apply.
+func (r ReflectType) Apply(target Function, this Valuer, args ...Valuer) (Value, error) {
+	a, err := newArray(args)
+	if err != nil {
+		return Undefined.Value, err
+	}
+
+	return r.Call("apply", target, this, a)
+}
+
+// Construct calls target as a constructor with the given arguments, like
+// Reflect.construct.
+func (r ReflectType) Construct(target Function, args ...Valuer) (Value, error) {
+	return r.ConstructNewTarget(target, target, args...)
+}
+
+// ConstructNewTarget calls target as a constructor with the given arguments,
+// using newTarget as the value of new.target, like Reflect.construct.
+func (r ReflectType) ConstructNewTarget(target, newTarget Function, args ...Valuer) (Value, error) {
+	a, err := newArray(args)
+	if err != nil {
+		return Undefined.Value, err
+	}
+
+	return r.Call("construct", target, a, newTarget)
+}
+
+// DefineProperty defines the own property k of o according to d, like
+// Reflect.defineProperty. It reports whether the property was defined.
+func (r ReflectType) DefineProperty(o Object, k PropertyKey, d Descriptor) (bool, error) {
+	return r.callBool("defineProperty", o, k, d)
+}
+
+// DeleteProperty deletes the property k of o, like Reflect.deleteProperty.
+// It reports whether the property was deleted.
+func (r ReflectType) DeleteProperty(o Object, k PropertyKey) (bool, error) {
+	return r.callBool("deleteProperty", o, k)
+}
+
+// Get returns the property k of o, like Reflect.get.
+func (r ReflectType) Get(o Object, k PropertyKey) (Value, error) {
+	return r.Call("get", o, k)
+}
+
+// GetOwnPropertyDescriptor returns the descriptor of the own property k of o,
+// like Reflect.getOwnPropertyDescriptor. ok is false if o has no such own
+// property.
+func (r ReflectType) GetOwnPropertyDescriptor(o Object, k PropertyKey) (d Descriptor, ok bool, err error) {
+	res, err := r.Call("getOwnPropertyDescriptor", o, k)
+	if err != nil {
+		return Descriptor{}, false, err
+	}
+
+	if res.IsUndefined() {
+		return Descriptor{}, false, nil
+	}
+
+	return descriptorOf(res), true, nil
+}
+
+// GetPrototypeOf returns the prototype of o, which is an object or null, like
+// Reflect.getPrototypeOf.
+func (r ReflectType) GetPrototypeOf(o Object) (Value, error) {
+	return r.Call("getPrototypeOf", o)
+}
+
+// Has reports whether o has the property k, either as an own property or
+// through its prototype chain, like Reflect.has.
+func (r ReflectType) Has(o Object, k PropertyKey) (bool, error) {
+	return r.callBool("has", o, k)
+}
+
+// IsExtensible reports whether new properties can be added to o, like
+// Reflect.isExtensible.
+func (r ReflectType) IsExtensible(o Object) (bool, error) {
+	return r.callBool("isExtensible", o)
+}
+
+// OwnKeys returns the own property keys of o, including symbols, like
+// Reflect.ownKeys.
+func (r ReflectType) OwnKeys(o Object) ([]PropertyKey, error) {
+	res, err := r.Call("ownKeys", o)
+	if err != nil {
+		return nil, err
+	}
+
+	keys := make([]PropertyKey, res.Length())
+	for i := range keys {
+		k, ok := PropertyKeyOf(res.Index(i))
+		if !ok {
+			panic("ownKeys returned a non-key")
+		}
+
+		keys[i] = k
+	}
+
+	return keys, nil
+}
+
+// PreventExtensions prevents new properties from being added to o, like
+// Reflect.preventExtensions. It reports whether o is now non-extensible.
+func (r ReflectType) PreventExtensions(o Object) (bool, error) {
+	return r.callBool("preventExtensions", o)
+}
+
+// Set sets the property k of o to ValueOf(x), like Reflect.set. It reports
+// whether the property was set.
+func (r ReflectType) Set(o Object, k PropertyKey, x any) (bool, error) {
+	return r.callBool("set", o, k, ValueOf(x))
+}
+
+// SetPrototypeOf sets the prototype of o to proto, which must be an object or
+// null, like Reflect.setPrototypeOf. It reports whether the prototype was set.
+func (r ReflectType) SetPrototypeOf(o Object, proto Valuer) (bool, error) {
+	return r.callBool("setPrototypeOf", o, proto)
+}
+
+// Constructor returns the constructor that v was created by, found through
+// the "constructor" property of its prototype. Primitives are boxed first,
+// so the constructor of 1 is Number. It returns undefined if v is null or
+// undefined, or if the prototype is null or has no such property.
+func (r ReflectType) Constructor(v Valuer) (Value, error) {
+	if x := v.ValueOf(); x.IsNull() || x.IsUndefined() {
+		return Undefined.Value, nil
+	}
+
+	o, err := ObjectConstructor.Invoke(v)
+	if err != nil {
+		return Undefined.Value, err
+	}
+
+	proto, err := r.GetPrototypeOf(Object{Value: o})
 	if err != nil {
-		panic("constructor: " + err.Error())
+		return Undefined.Value, err
 	}
 
-	o, ok := ObjectOf(con)
-	if !ok {
-		panic("not object")
+	if proto.IsNull() {
+		return Undefined.Value, nil
 	}
 
-	return o
+	return r.Get(Object{Value: proto}, StringKey("constructor"))
 }
 
-func (r ReflectType) Get(o Object, p String) Value {
-	v, err := r.Call("get", o, p)
+func (r ReflectType) callBool(m string, args ...Valuer) (bool, error) {
+	res, err := r.Call(m, args...)
 	if err != nil {
-		panic("get: " + err.Error())
+		return false, err
 	}
 
-	return v
+	return res.Truthy(), nil
 }
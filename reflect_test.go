@@ -0,0 +1,96 @@
+//go:build wasm && js
+
+package gs_test
+
+import (
+	"testing"
+
+	"github.com/superloach/gs"
+)
+
+func TestReflect(t *testing.T) {
+	r := gs.Reflect
+
+	c, err := r.Constructor(gs.ValueOf(1))
+	if err != nil || !c.Equal(gs.NumberConstructor.Value) {
+		t.Errorf("Constructor(1): got %v, %v", c, err)
+	}
+
+	c, err = r.Constructor(eval(t, "Object.create(null)"))
+	if err != nil || !c.IsUndefined() {
+		t.Errorf("Constructor(Object.create(null)): got %v, %v", c, err)
+	}
+
+	for _, x := range []gs.Value{gs.Null.Value, gs.Undefined.Value} {
+		if c, err := r.Constructor(x); err != nil || !c.IsUndefined() {
+			t.Errorf("Constructor(%v): got %v, %v", x, c, err)
+		}
+	}
+
+	max, _ := gs.FunctionOf(eval(t, "Math.max"))
+	v, err := r.Apply(max, gs.Undefined, gs.ValueOf(1), gs.ValueOf(3), gs.ValueOf(2))
+	if err != nil || v.Int() != 3 {
+		t.Errorf("Apply: got %v, %v", v, err)
+	}
+
+	v, err = r.Construct(gs.Function{Value: gs.Global.Get("Date")}, gs.ValueOf(0))
+	if err != nil || v.Type() != gs.TypeObject {
+		t.Errorf("Construct: got %v, %v", v, err)
+	}
+
+	o, _ := gs.ObjectOf(eval(t, "({a: 1, [Symbol.iterator]: null})"))
+
+	keys, err := r.OwnKeys(o)
+	if err != nil || len(keys) != 2 || keys[0].String() != "a" {
+		t.Fatalf("OwnKeys: got %v, %v", keys, err)
+	}
+
+	if _, ok := keys[1].Symbol(); !ok {
+		t.Error("OwnKeys: expected symbol key")
+	}
+
+	if ok, err := r.Set(o, gs.StringKey("b"), 2); !ok || err != nil {
+		t.Errorf("Set: got %v, %v", ok, err)
+	}
+
+	if v, err := r.Get(o, gs.StringKey("b")); err != nil || v.Int() != 2 {
+		t.Errorf("Get: got %v, %v", v, err)
+	}
+
+	if ok, err := r.DeleteProperty(o, gs.StringKey("b")); !ok || err != nil {
+		t.Errorf("DeleteProperty: got %v, %v", ok, err)
+	}
+
+	if ok, _ := r.Has(o, gs.StringKey("b")); ok {
+		t.Error("Has: deleted property still present")
+	}
+
+	if ok, _ := r.SetPrototypeOf(o, gs.Null); !ok {
+		t.Error("SetPrototypeOf(null) failed")
+	}
+
+	if proto, _ := r.GetPrototypeOf(o); !proto.IsNull() {
+		t.Errorf("GetPrototypeOf: got %v", proto)
+	}
+
+	if ok, _ := r.PreventExtensions(o); !ok {
+		t.Error("PreventExtensions failed")
+	}
+
+	if ok, _ := r.IsExtensible(o); ok {
+		t.Error("IsExtensible after PreventExtensions")
+	}
+
+	if ok, _ := r.DefineProperty(o, gs.StringKey("c"), gs.Descriptor{}); ok {
+		t.Error("DefineProperty on non-extensible object succeeded")
+	}
+
+	d, ok, err := r.GetOwnPropertyDescriptor(o, gs.StringKey("a"))
+	if err != nil || !ok || d.Value.Int() != 1 || !d.Writable {
+		t.Errorf("GetOwnPropertyDescriptor: got %+v, %v, %v", d, ok, err)
+	}
+
+	if _, err := r.OwnKeys(gs.Object{Value: gs.ValueOf(1)}); err == nil {
+		t.Error("expected TypeError for primitive target")
+	}
+}
@@ -4,15 +4,106 @@ package gs
 
 var _ Valuer = Symbol{}
 
-// TODO: fill with well-known symbols (6.1.5.1)
+var SymbolConstructor = Function{Value: Global.Get("Symbol")}
+
+// Well-known symbols (6.1.5.1)
 var (
-	_ Symbol
+	SymbolAsyncIterator      = wellKnownSymbol("asyncIterator")
+	SymbolHasInstance        = wellKnownSymbol("hasInstance")
+	SymbolIsConcatSpreadable = wellKnownSymbol("isConcatSpreadable")
+	SymbolIterator           = wellKnownSymbol("iterator")
+	SymbolMatch              = wellKnownSymbol("match")
+	SymbolMatchAll           = wellKnownSymbol("matchAll")
+	SymbolReplace            = wellKnownSymbol("replace")
+	SymbolSearch             = wellKnownSymbol("search")
+	SymbolSpecies            = wellKnownSymbol("species")
+	SymbolSplit              = wellKnownSymbol("split")
+	SymbolToPrimitive        = wellKnownSymbol("toPrimitive")
+	SymbolToStringTag        = wellKnownSymbol("toStringTag")
+	SymbolUnscopables        = wellKnownSymbol("unscopables")
 )
 
+func wellKnownSymbol(name string) Symbol {
+	s, ok := SymbolOf(Object{Value: SymbolConstructor.Value}.Get(name))
+	if !ok {
+		panic("Symbol." + name + " is not a symbol")
+	}
+
+	return s
+}
+
+// Symbol is a JavaScript symbol primitive.
 type Symbol struct {
 	Value
 }
 
+// SymbolOf converts a JavaScript value into a Symbol, if it is a symbol.
+func SymbolOf(v Valuer) (Symbol, bool) {
+	vv := v.ValueOf()
+
+	if vv.Type() != TypeSymbol {
+		return Symbol{}, false
+	}
+
+	return Symbol{
+		Value: vv,
+	}, true
+}
+
+// NewSymbol returns a new unique symbol with the given description.
+func NewSymbol(description string) Symbol {
+	s, err := SymbolConstructor.Invoke(ToString(description))
+	if err != nil {
+		panic("Symbol: " + err.Error())
+	}
+
+	return Symbol{Value: s}
+}
+
+// SymbolFor returns the symbol registered under key in the global symbol
+// registry, creating it if needed, like JavaScript's Symbol.for.
+func SymbolFor(key string) Symbol {
+	s, err := Object{Value: SymbolConstructor.Value}.Call("for", ToString(key))
+	if err != nil {
+		panic("Symbol.for: " + err.Error())
+	}
+
+	return Symbol{Value: s}
+}
+
+// SymbolKeyFor returns the key that s is registered under in the global
+// symbol registry, like JavaScript's Symbol.keyFor. ok is false if s was not
+// created with SymbolFor.
+func SymbolKeyFor(s Symbol) (key string, ok bool) {
+	k, err := Object{Value: SymbolConstructor.Value}.Call("keyFor", s)
+	if err != nil {
+		panic("Symbol.keyFor: " + err.Error())
+	}
+
+	if k.IsUndefined() {
+		return "", false
+	}
+
+	return jsString(k), true
+}
+
 func (s Symbol) ValueOf() Value {
 	return s.Value
 }
+
+// Description returns the description of s. ok is false if s was created
+// without one.
+func (s Symbol) Description() (description string, ok bool) {
+	// properties can't be read from primitives directly, so box s first
+	boxed, err := ObjectConstructor.Invoke(s)
+	if err != nil {
+		panic("Object(symbol): " + err.Error())
+	}
+
+	d := Object{Value: boxed}.Get("description")
+	if d.IsUndefined() {
+		return "", false
+	}
+
+	return jsString(d), true
+}
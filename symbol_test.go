@@ -0,0 +1,49 @@
+//go:build wasm && js
+
+package gs_test
+
+import (
+	"testing"
+
+	"github.com/superloach/gs"
+)
+
+func TestSymbol(t *testing.T) {
+	if !gs.SymbolIterator.Equal(eval(t, "Symbol.iterator")) {
+		t.Error("SymbolIterator is not Symbol.iterator")
+	}
+
+	if d, ok := gs.SymbolToStringTag.Description(); !ok || d != "Symbol.toStringTag" {
+		t.Errorf("SymbolToStringTag description: got %q, %v", d, ok)
+	}
+
+	s := gs.NewSymbol("foo")
+	if d, ok := s.Description(); !ok || d != "foo" {
+		t.Errorf("description: got %q, %v", d, ok)
+	}
+
+	if s.Equal(gs.NewSymbol("foo").Value) {
+		t.Error("NewSymbol should return unique symbols")
+	}
+
+	if _, ok := gs.SymbolKeyFor(s); ok {
+		t.Error("unregistered symbol has a key")
+	}
+
+	r := gs.SymbolFor("app.key")
+	if !r.Equal(gs.SymbolFor("app.key").Value) {
+		t.Error("SymbolFor should return the registered symbol")
+	}
+
+	if k, ok := gs.SymbolKeyFor(r); !ok || k != "app.key" {
+		t.Errorf("key: got %q, %v", k, ok)
+	}
+
+	if _, ok := gs.SymbolOf(gs.ToString("foo")); ok {
+		t.Error("SymbolOf should reject strings")
+	}
+
+	if _, ok := (gs.Symbol{Value: eval(t, "Symbol()")}).Description(); ok {
+		t.Error("Symbol() should have no description")
+	}
+}
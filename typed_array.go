@@ -0,0 +1,138 @@
+//go:build wasm && js
+
+package gs
+
+import (
+	"runtime"
+	"unsafe"
+)
+
+// TypedArray holds what all JavaScript typed arrays have in common. It is
+// embedded in the specific typed array types, such as Float32Array.
+type TypedArray struct {
+	Object
+}
+
+func (a TypedArray) ValueOf() Value {
+	return a.Value
+}
+
+// Len returns the number of elements in a.
+func (a TypedArray) Len() int {
+	return a.Length()
+}
+
+// Buffer returns the buffer that a is a view of, which is either an
+// ArrayBuffer or a SharedArrayBuffer.
+func (a TypedArray) Buffer() Object {
+	return Object{Value: a.Get("buffer")}
+}
+
+// Detached reports whether the buffer of a has been detached, such as by
+// ArrayBuffer.Transfer or by the Go memory growing under a view from
+// ViewBytes. A detached array is empty.
+func (a TypedArray) Detached() bool {
+	b := Object{Value: a.Get("buffer")}
+
+	d := b.Get("detached")
+	if d.IsUndefined() {
+		// engines without ArrayBuffer.prototype.detached; wasm memory is
+		// never empty, so this only misses empty buffers
+		return b.Get("byteLength").Int() == 0
+	}
+
+	return d.Truthy()
+}
+
+// ByteOffset returns the offset in bytes of a from the start of its buffer.
+func (a TypedArray) ByteOffset() int {
+	return a.Get("byteOffset").Int()
+}
+
+// ByteLength returns the length in bytes of a.
+func (a TypedArray) ByteLength() int {
+	return a.Get("byteLength").Int()
+}
+
+func typedArrayOf(v Valuer, constructor Function) (TypedArray, bool) {
+	o, ok := ObjectOf(v)
+	if !ok {
+		return TypedArray{}, false
+	}
+
+	if !o.InstanceOf(constructor.Value) {
+		return TypedArray{}, false
+	}
+
+	return TypedArray{Object: o}, true
+}
+
+func newTypedArray(constructor Function, length int) (TypedArray, error) {
+	a, err := constructor.New(ValueOf(length))
+	if err != nil {
+		return TypedArray{}, err
+	}
+
+	return TypedArray{Object: Object{Value: a}}, nil
+}
+
+func (a TypedArray) subarray(begin, end int) TypedArray {
+	s, err := a.Call("subarray", ValueOf(begin), ValueOf(end))
+	if err != nil {
+		panic("subarray: " + err.Error())
+	}
+
+	return TypedArray{Object: Object{Value: s}}
+}
+
+// bytes returns a Uint8Array viewing the first n bytes of a.
+func (a TypedArray) bytes(n int) Uint8Array {
+	return byteView(a.Get("buffer"), a.ByteOffset(), n)
+}
+
+// asBytes returns the memory of s as a byte slice.
+func asBytes[T any](s []T) []byte {
+	if len(s) == 0 {
+		return nil
+	}
+
+	var zero T
+	return unsafe.Slice((*byte)(unsafe.Pointer(&s[0])), len(s)*int(unsafe.Sizeof(zero)))
+}
+
+// copyToGo copies elements from a to dst by reinterpreting their bytes, which
+// relies on JavaScript and Go both being little-endian under js/wasm.
+func copyToGo[T any](a TypedArray, dst []T) int {
+	n := a.Len()
+	if len(dst) < n {
+		n = len(dst)
+	}
+
+	if n == 0 {
+		return 0
+	}
+
+	b := asBytes(dst[:n])
+	a.bytes(len(b)).CopyBytesToGo(b)
+	runtime.KeepAlive(dst)
+
+	return n
+}
+
+// copyFromGo copies elements from src to a by reinterpreting their bytes.
+func copyFromGo[T any](a TypedArray, src []T) int {
+	n := a.Len()
+	if len(src) < n {
+		n = len(src)
+	}
+
+	if n == 0 {
+		return 0
+	}
+
+	b := asBytes(src[:n])
+	a.bytes(len(b)).CopyBytesToJS(b)
+	runtime.KeepAlive(src)
+
+	return n
+}
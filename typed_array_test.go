@@ -0,0 +1,89 @@
+//go:build wasm && js
+
+package gs_test
+
+import (
+	"math"
+	"reflect"
+	"testing"
+
+	"github.com/superloach/gs"
+)
+
+func TestFloat32Array(t *testing.T) {
+	src := []float32{1.5, -2, float32(math.Inf(1)), 3.25}
+
+	a, err := gs.NewFloat32Array(len(src))
+	if err != nil {
+		t.Fatalf("new: %v", err)
+	}
+
+	if n := a.CopyFromGo(src); n != len(src) {
+		t.Errorf("CopyFromGo: copied %d", n)
+	}
+
+	if v := a.Index(3).Float(); v != 3.25 {
+		t.Errorf("element 3: got %v", v)
+	}
+
+	dst := make([]float32, 8)
+	if n := a.CopyToGo(dst); n != len(src) || !reflect.DeepEqual(dst[:n], src) {
+		t.Errorf("CopyToGo: got %v (%d)", dst, n)
+	}
+
+	if a.ByteLength() != 16 || a.Len() != 4 {
+		t.Errorf("ByteLength/Len: got %d/%d", a.ByteLength(), a.Len())
+	}
+}
+
+func TestTypedArraySubarray(t *testing.T) {
+	a, ok := gs.Int16ArrayOf(eval(t, "new Int16Array([1, -2, 3, -4, 5])"))
+	if !ok {
+		t.Fatal("Int16ArrayOf rejected an Int16Array")
+	}
+
+	sub := a.Subarray(1, -1)
+	if sub.ByteOffset() != 2 || sub.Len() != 3 {
+		t.Errorf("subarray offset/len: got %d/%d", sub.ByteOffset(), sub.Len())
+	}
+
+	dst := make([]int16, 3)
+	sub.CopyToGo(dst)
+	if !reflect.DeepEqual(dst, []int16{-2, 3, -4}) {
+		t.Errorf("subarray CopyToGo: got %v", dst)
+	}
+
+	sub.CopyFromGo([]int16{20})
+	if v := a.Index(1).Int(); v != 20 {
+		t.Errorf("write through subarray: got %d", v)
+	}
+
+	if !sub.Buffer().Equal(a.Buffer().Value) {
+		t.Error("subarray does not share its buffer")
+	}
+
+	if _, ok := gs.Int32ArrayOf(a); ok {
+		t.Error("Int32ArrayOf accepted an Int16Array")
+	}
+}
+
+func TestBigInt64Array(t *testing.T) {
+	a, err := gs.NewBigInt64Array(2)
+	if err != nil {
+		t.Fatalf("new: %v", err)
+	}
+
+	a.CopyFromGo([]int64{math.MinInt64, math.MaxInt64})
+
+	b, ok := gs.BigIntOf(a.Index(0))
+	if x, _ := b.Int64(); !ok || x != math.MinInt64 {
+		t.Errorf("element 0: got %v", b)
+	}
+
+	dst := make([]uint64, 2)
+	u, _ := gs.BigUint64ArrayOf(eval(t, "new BigUint64Array([2n ** 64n - 1n, 7n])"))
+	u.CopyToGo(dst)
+	if dst[0] != math.MaxUint64 || dst[1] != 7 {
+		t.Errorf("BigUint64Array CopyToGo: got %v", dst)
+	}
+}
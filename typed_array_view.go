@@ -0,0 +1,306 @@
+//go:build wasm && js
+
+package gs
+
+import (
+	"encoding/json"
+	"errors"
+	"math"
+	"sync"
+)
+
+// Codec converts between JavaScript values and Go values of type T.
+type Codec[T any] interface {
+	Decode(Value) (T, error)
+	Encode(T) (Value, error)
+}
+
+// BulkCodec is a Codec that can also convert whole arrays with a fixed number
+// of calls into JavaScript, rather than one or more per element.
+type BulkCodec[T any] interface {
+	Codec[T]
+	DecodeArray(Array) ([]T, error)
+	EncodeArray([]T) (Array, error)
+}
+
+// ValueCodec converts values with Unmarshal and Marshal, so T can be anything
+// they support, including tagged structs.
+type ValueCodec[T any] struct{}
+
+func (ValueCodec[T]) Decode(v Value) (T, error) {
+	var x T
+	err := Unmarshal(v, &x)
+	return x, err
+}
+
+func (ValueCodec[T]) Encode(x T) (Value, error) {
+	return Marshal(x)
+}
+
+// StringCodec converts JavaScript strings to Go strings.
+type StringCodec struct {
+	ValueCodec[string]
+}
+
+func (StringCodec) DecodeArray(a Array) ([]string, error) {
+	return decodeJSONArray[string](a, "string")
+}
+
+func (StringCodec) EncodeArray(s []string) (Array, error) {
+	return encodeJSONArray(s)
+}
+
+// Float64Codec converts JavaScript numbers to float64s.
+//
+// Like any number passed between Go and JavaScript one at a time, -0 becomes
+// 0, and the array methods do the same, so the result does not depend on
+// which path is taken.
+type Float64Codec struct {
+	ValueCodec[float64]
+}
+
+func (Float64Codec) DecodeArray(a Array) ([]float64, error) {
+	// JSON.stringify already writes -0 as 0
+	return decodeJSONArray[float64](a, "number")
+}
+
+func (Float64Codec) EncodeArray(s []float64) (Array, error) {
+	for i, x := range s {
+		if x == 0 && math.Signbit(x) {
+			// json.Marshal would keep -0, so copy s without it
+			s = append([]float64(nil), s...)
+			for j := i; j < len(s); j++ {
+				if s[j] == 0 {
+					s[j] = 0
+				}
+			}
+
+			break
+		}
+	}
+
+	return encodeJSONArray(s)
+}
+
+// BoolCodec converts JavaScript booleans to bools.
+type BoolCodec struct {
+	ValueCodec[bool]
+}
+
+func (BoolCodec) DecodeArray(a Array) ([]bool, error) {
+	return decodeJSONArray[bool](a, "boolean")
+}
+
+func (BoolCodec) EncodeArray(s []bool) (Array, error) {
+	return encodeJSONArray(s)
+}
+
+var jsonObject = Object{Value: Global.Get("JSON")}
+
+// errNotJSON reports that an array can't take the JSON fast path, and must be
+// converted an element at a time instead.
+var errNotJSON = errors.New("array not representable as JSON")
+
+// stringifyArraySource is a JSON.stringify for arrays whose elements must all
+// be primitives of the JavaScript type typ; it returns undefined otherwise,
+// rather than calling toJSON and unwrapping boxed primitives, which would
+// decode Dates and String objects as strings.
+const stringifyArraySource = `
+	for (let i = 0; i < a.length; i++) {
+		if (typeof a[i] !== typ) return undefined;
+	}
+	return JSON.stringify(a);
+`
+
+// stringifyArray returns the function defined by stringifyArraySource, which
+// is created with the Function constructor the first time it is needed.
+var stringifyArray = sync.OnceValues(func() (Function, error) {
+	f, err := Function{Value: Global.Get("Function")}.New(ToString("a"), ToString("typ"), ToString(stringifyArraySource))
+	return Function{Value: f}, err
+})
+
+// decodeJSONArray decodes a in two calls into JavaScript, by stringifying it
+// and parsing the result in Go. It returns errNotJSON if any element is not
+// of the JavaScript type typ, or does not survive the trip, such as NaN.
+func decodeJSONArray[T any](a Array, typ string) ([]T, error) {
+	stringify, err := stringifyArray()
+	if err != nil {
+		return nil, errNotJSON
+	}
+
+	s, err := stringify.Invoke(a, ToString(typ))
+	if err != nil || s.Type() != TypeString {
+		return nil, errNotJSON
+	}
+
+	var raw []json.RawMessage
+	if err := json.Unmarshal([]byte(jsString(s)), &raw); err != nil {
+		return nil, errNotJSON
+	}
+
+	out := make([]T, len(raw))
+	for i, r := range raw {
+		if string(r) == "null" {
+			return nil, errNotJSON
+		}
+
+		if err := json.Unmarshal(r, &out[i]); err != nil {
+			return nil, errNotJSON
+		}
+	}
+
+	return out, nil
+}
+
+// encodeJSONArray encodes s in two calls into JavaScript, by marshaling it in
+// Go and parsing the result in JavaScript. It returns errNotJSON if s can't be
+// represented as JSON, such as NaN.
+func encodeJSONArray[T any](s []T) (Array, error) {
+	b, err := json.Marshal(s)
+	if err != nil {
+		return Array{}, errNotJSON
+	}
+
+	v, err := jsonObject.Call("parse", ToString(string(b)))
+	if err != nil {
+		return Array{}, errNotJSON
+	}
+
+	return Array{Object: Object{Value: v}}, nil
+}
+
+// TypedArrayView is a view of a JavaScript array whose elements are converted
+// to and from T by a Codec as they are accessed.
+type TypedArrayView[T any] struct {
+	Array
+	Codec Codec[T]
+}
+
+// NewTypedArrayView returns a view of a whose elements are converted by c.
+func NewTypedArrayView[T any](a Array, c Codec[T]) TypedArrayView[T] {
+	return TypedArrayView[T]{Array: a, Codec: c}
+}
+
+// FromSlice returns a view of a new JavaScript array holding the elements of
+// s, encoded by c. If c is a BulkCodec, the array is built with as few calls
+// into JavaScript as it allows.
+func FromSlice[T any](s []T, c Codec[T]) (TypedArrayView[T], error) {
+	if bc, ok := c.(BulkCodec[T]); ok {
+		a, err := bc.EncodeArray(s)
+		if err == nil {
+			return NewTypedArrayView(a, c), nil
+		}
+
+		if !errors.Is(err, errNotJSON) {
+			return TypedArrayView[T]{}, err
+		}
+	}
+
+	elems := make([]Valuer, len(s))
+	for i, x := range s {
+		v, err := c.Encode(x)
+		if err != nil {
+			return TypedArrayView[T]{}, err
+		}
+
+		elems[i] = v
+	}
+
+	a, err := NewArray(elems...)
+	if err != nil {
+		return TypedArrayView[T]{}, err
+	}
+
+	return NewTypedArrayView(a, c), nil
+}
+
+// At decodes the element of the array at index i, counting back from the end
+// if i is negative.
+func (v TypedArrayView[T]) At(i int) (T, error) {
+	e, err := v.Array.At(i)
+	if err != nil {
+		var zero T
+		return zero, err
+	}
+
+	x, err := v.Codec.Decode(e)
+	return x, atIndex(err, i)
+}
+
+// SetAt encodes x and stores it at index i of the array.
+func (v TypedArrayView[T]) SetAt(i int, x T) error {
+	e, err := v.Codec.Encode(x)
+	if err != nil {
+		return err
+	}
+
+	_, err = Reflect.Set(v.Object, IndexKey(i), e)
+	return err
+}
+
+// Append encodes xs and appends them to the array.
+func (v TypedArrayView[T]) Append(xs ...T) error {
+	elems := make([]Valuer, len(xs))
+	for i, x := range xs {
+		e, err := v.Codec.Encode(x)
+		if err != nil {
+			return err
+		}
+
+		elems[i] = e
+	}
+
+	_, err := v.Push(elems...)
+	return err
+}
+
+// ToSlice decodes every element of the array. If the codec is a BulkCodec,
+// this is done with as few calls into JavaScript as it allows.
+func (v TypedArrayView[T]) ToSlice() ([]T, error) {
+	if bc, ok := v.Codec.(BulkCodec[T]); ok {
+		s, err := bc.DecodeArray(v.Array)
+		if err == nil {
+			return s, nil
+		}
+
+		// fall through to get the precise error or unusual values
+		if !errors.Is(err, errNotJSON) {
+			return nil, err
+		}
+	}
+
+	s := make([]T, v.Len())
+	for i := range s {
+		x, err := v.At(i)
+		if err != nil {
+			return nil, err
+		}
+
+		s[i] = x
+	}
+
+	return s, nil
+}
+
+// atIndex adds the array index i to the path of decoding errors.
+func atIndex(err error, i int) error {
+	var ute *UnmarshalTypeError
+	if errors.As(err, &ute) {
+		ute.Path = indexPath("", i) + joinPath(ute.Path)
+	}
+
+	var ue *UnmarshalerError
+	if errors.As(err, &ue) {
+		ue.Path = indexPath("", i) + joinPath(ue.Path)
+	}
+
+	return err
+}
+
+func joinPath(path string) string {
+	if path == "" || path[0] == '[' {
+		return path
+	}
+
+	return "." + path
+}
@@ -0,0 +1,115 @@
+//go:build wasm && js
+
+package gs_test
+
+import (
+	"errors"
+	"math"
+	"reflect"
+	"testing"
+
+	"github.com/superloach/gs"
+)
+
+func TestTypedArrayView(t *testing.T) {
+	a, _ := gs.ArrayOf(eval(t, `["a", "bé", "<c>"]`))
+	strs := gs.NewTypedArrayView[string](a, gs.StringCodec{})
+
+	got, err := strs.ToSlice()
+	if err != nil || !reflect.DeepEqual(got, []string{"a", "bé", "<c>"}) {
+		t.Errorf("ToSlice: got %q, %v", got, err)
+	}
+
+	if err := strs.SetAt(0, "z"); err != nil {
+		t.Fatal(err)
+	}
+
+	if s, err := strs.At(-3); err != nil || s != "z" {
+		t.Errorf("At: got %q, %v", s, err)
+	}
+
+	floats, err := gs.FromSlice[float64]([]float64{1.5, math.NaN(), 1e300}, gs.Float64Codec{})
+	if err != nil {
+		t.Fatalf("FromSlice: %v", err)
+	}
+
+	fs, err := floats.ToSlice()
+	if err != nil || len(fs) != 3 || fs[0] != 1.5 || !math.IsNaN(fs[1]) || fs[2] != 1e300 {
+		t.Errorf("float round trip: got %v, %v", fs, err)
+	}
+
+	bools, err := gs.FromSlice[bool]([]bool{true, false}, gs.BoolCodec{})
+	if err != nil || join(t, bools.Array) != "true,false" {
+		t.Errorf("bools: got %v, %v", bools, err)
+	}
+}
+
+type viewPoint struct {
+	X int `js:"x"`
+	Y int `js:"y"`
+}
+
+func TestTypedArrayViewStructs(t *testing.T) {
+	a, _ := gs.ArrayOf(eval(t, `[{x: 1, y: 2}, {x: 3, y: "four"}]`))
+	points := gs.NewTypedArrayView[viewPoint](a, gs.ValueCodec[viewPoint]{})
+
+	if p, err := points.At(0); err != nil || p != (viewPoint{1, 2}) {
+		t.Errorf("At: got %+v, %v", p, err)
+	}
+
+	_, err := points.ToSlice()
+
+	var ute *gs.UnmarshalTypeError
+	if !errors.As(err, &ute) || ute.Path != "[1].y" {
+		t.Errorf("expected error at [1].y, got %v", err)
+	}
+
+	if err := points.Append(viewPoint{5, 6}); err != nil || points.Len() != 3 {
+		t.Errorf("Append: got len %d, %v", points.Len(), err)
+	}
+
+	strs := gs.NewTypedArrayView[string](a, gs.StringCodec{})
+	if _, err := strs.ToSlice(); !errors.As(err, &ute) || ute.Path != "[0]" {
+		t.Errorf("expected error at [0], got %v", err)
+	}
+}
+
+func TestTypedArrayViewBulkTypes(t *testing.T) {
+	a, _ := gs.ArrayOf(eval(t, `["a", new Date(0)]`))
+	strs := gs.NewTypedArrayView[string](a, gs.StringCodec{})
+
+	var ute *gs.UnmarshalTypeError
+	if _, err := strs.ToSlice(); !errors.As(err, &ute) || ute.Path != "[1]" {
+		t.Errorf("Date: expected error at [1], got %v", err)
+	}
+
+	a, _ = gs.ArrayOf(eval(t, `[new String("x")]`))
+	strs = gs.NewTypedArrayView[string](a, gs.StringCodec{})
+	if _, err := strs.ToSlice(); !errors.As(err, &ute) || ute.Path != "[0]" {
+		t.Errorf("String object: expected error at [0], got %v", err)
+	}
+
+}
+
+func TestTypedArrayViewNegativeZero(t *testing.T) {
+	// NaN makes the bulk path fall back to decoding an element at a time
+	for _, src := range []string{"[1, -0]", "[NaN, -0]"} {
+		a, _ := gs.ArrayOf(eval(t, src))
+		floats := gs.NewTypedArrayView[float64](a, gs.Float64Codec{})
+		if fs, err := floats.ToSlice(); err != nil || len(fs) != 2 || fs[1] != 0 || math.Signbit(fs[1]) {
+			t.Errorf("%s: got %v, %v", src, fs, err)
+		}
+	}
+
+	isNegZero := gs.Function{Value: eval(t, "a => Object.is(a[1], -0)")}
+	for _, s := range [][]float64{{1, math.Copysign(0, -1)}, {math.NaN(), math.Copysign(0, -1)}} {
+		floats, err := gs.FromSlice[float64](s, gs.Float64Codec{})
+		if err != nil {
+			t.Fatalf("FromSlice: %v", err)
+		}
+
+		if v, err := isNegZero.Invoke(floats); err != nil || v.Truthy() {
+			t.Errorf("FromSlice(%v): got -0 in JavaScript", s)
+		}
+	}
+}
@@ -0,0 +1,171 @@
+//go:build wasm && js
+
+package gs
+
+var (
+	Int8ArrayConstructor      = Function{Value: Global.Get("Int8Array")}
+	Uint16ArrayConstructor    = Function{Value: Global.Get("Uint16Array")}
+	Int16ArrayConstructor     = Function{Value: Global.Get("Int16Array")}
+	Uint32ArrayConstructor    = Function{Value: Global.Get("Uint32Array")}
+	Int32ArrayConstructor     = Function{Value: Global.Get("Int32Array")}
+	Float32ArrayConstructor   = Function{Value: Global.Get("Float32Array")}
+	Float64ArrayConstructor   = Function{Value: Global.Get("Float64Array")}
+	BigInt64ArrayConstructor  = Function{Value: Global.Get("BigInt64Array")}
+	BigUint64ArrayConstructor = Function{Value: Global.Get("BigUint64Array")}
+)
+
+// NumericElement is the type of the elements of a NumericArray.
+type NumericElement interface {
+	int8 | uint16 | int16 | uint32 | int32 | float32 | float64 | int64 | uint64
+}
+
+// NumericArray is a JavaScript typed array whose elements are Ts, such as an
+// Int8Array for int8 or a BigInt64Array for int64. Uint8Array and
+// Uint8ClampedArray have types of their own.
+type NumericArray[T NumericElement] struct {
+	TypedArray
+}
+
+type (
+	// Int8Array is a JavaScript Int8Array, whose elements are int8s.
+	Int8Array = NumericArray[int8]
+
+	// Uint16Array is a JavaScript Uint16Array, whose elements are uint16s.
+	Uint16Array = NumericArray[uint16]
+
+	// Int16Array is a JavaScript Int16Array, whose elements are int16s.
+	Int16Array = NumericArray[int16]
+
+	// Uint32Array is a JavaScript Uint32Array, whose elements are uint32s.
+	Uint32Array = NumericArray[uint32]
+
+	// Int32Array is a JavaScript Int32Array, whose elements are int32s.
+	Int32Array = NumericArray[int32]
+
+	// Float32Array is a JavaScript Float32Array, whose elements are float32s.
+	Float32Array = NumericArray[float32]
+
+	// Float64Array is a JavaScript Float64Array, whose elements are float64s.
+	Float64Array = NumericArray[float64]
+
+	// BigInt64Array is a JavaScript BigInt64Array, whose elements are int64s.
+	BigInt64Array = NumericArray[int64]
+
+	// BigUint64Array is a JavaScript BigUint64Array, whose elements are
+	// uint64s.
+	BigUint64Array = NumericArray[uint64]
+)
+
+// numericArrayConstructor returns the constructor of the typed array whose
+// elements are Ts.
+func numericArrayConstructor[T NumericElement]() Function {
+	var x T
+	switch any(x).(type) {
+	case int8:
+		return Int8ArrayConstructor
+	case uint16:
+		return Uint16ArrayConstructor
+	case int16:
+		return Int16ArrayConstructor
+	case uint32:
+		return Uint32ArrayConstructor
+	case int32:
+		return Int32ArrayConstructor
+	case float32:
+		return Float32ArrayConstructor
+	case float64:
+		return Float64ArrayConstructor
+	case int64:
+		return BigInt64ArrayConstructor
+	case uint64:
+		return BigUint64ArrayConstructor
+	}
+
+	panic("unknown typed array element type")
+}
+
+// NumericArrayOf converts a JavaScript value into a NumericArray, if it is
+// the typed array whose elements are Ts.
+func NumericArrayOf[T NumericElement](v Valuer) (NumericArray[T], bool) {
+	a, ok := typedArrayOf(v, numericArrayConstructor[T]())
+	return NumericArray[T]{TypedArray: a}, ok
+}
+
+// NewNumericArray returns a new typed array of the given length, whose
+// elements are Ts, filled with zeros.
+func NewNumericArray[T NumericElement](length int) (NumericArray[T], error) {
+	a, err := newTypedArray(numericArrayConstructor[T](), length)
+	return NumericArray[T]{TypedArray: a}, err
+}
+
+// Subarray returns a view of the elements of a from begin up to end, which
+// shares its buffer. Negative indices count back from the end.
+func (a NumericArray[T]) Subarray(begin, end int) NumericArray[T] {
+	return NumericArray[T]{TypedArray: a.subarray(begin, end)}
+}
+
+// CopyToGo copies elements from a to dst.
+// It returns the number of elements copied, which will be the minimum of the lengths of a and dst.
+func (a NumericArray[T]) CopyToGo(dst []T) int {
+	return copyToGo(a.TypedArray, dst)
+}
+
+// CopyFromGo copies elements from src to a.
+// It returns the number of elements copied, which will be the minimum of the lengths of src and a.
+func (a NumericArray[T]) CopyFromGo(src []T) int {
+	return copyFromGo(a.TypedArray, src)
+}
+
+// Int8ArrayOf converts a JavaScript value into an Int8Array, if it is one.
+func Int8ArrayOf(v Valuer) (Int8Array, bool) { return NumericArrayOf[int8](v) }
+
+// NewInt8Array returns a new Int8Array of the given length, filled with zeros.
+func NewInt8Array(length int) (Int8Array, error) { return NewNumericArray[int8](length) }
+
+// Uint16ArrayOf converts a JavaScript value into a Uint16Array, if it is one.
+func Uint16ArrayOf(v Valuer) (Uint16Array, bool) { return NumericArrayOf[uint16](v) }
+
+// NewUint16Array returns a new Uint16Array of the given length, filled with zeros.
+func NewUint16Array(length int) (Uint16Array, error) { return NewNumericArray[uint16](length) }
+
+// Int16ArrayOf converts a JavaScript value into an Int16Array, if it is one.
+func Int16ArrayOf(v Valuer) (Int16Array, bool) { return NumericArrayOf[int16](v) }
+
+// NewInt16Array returns a new Int16Array of the given length, filled with zeros.
+func NewInt16Array(length int) (Int16Array, error) { return NewNumericArray[int16](length) }
+
+// Uint32ArrayOf converts a JavaScript value into a Uint32Array, if it is one.
+func Uint32ArrayOf(v Valuer) (Uint32Array, bool) { return NumericArrayOf[uint32](v) }
+
+// NewUint32Array returns a new Uint32Array of the given length, filled with zeros.
+func NewUint32Array(length int) (Uint32Array, error) { return NewNumericArray[uint32](length) }
+
+// Int32ArrayOf converts a JavaScript value into an Int32Array, if it is one.
+func Int32ArrayOf(v Valuer) (Int32Array, bool) { return NumericArrayOf[int32](v) }
+
+// NewInt32Array returns a new Int32Array of the given length, filled with zeros.
+func NewInt32Array(length int) (Int32Array, error) { return NewNumericArray[int32](length) }
+
+// Float32ArrayOf converts a JavaScript value into a Float32Array, if it is one.
+func Float32ArrayOf(v Valuer) (Float32Array, bool) { return NumericArrayOf[float32](v) }
+
+// NewFloat32Array returns a new Float32Array of the given length, filled with zeros.
+func NewFloat32Array(length int) (Float32Array, error) { return NewNumericArray[float32](length) }
+
+// Float64ArrayOf converts a JavaScript value into a Float64Array, if it is one.
+func Float64ArrayOf(v Valuer) (Float64Array, bool) { return NumericArrayOf[float64](v) }
+
+// NewFloat64Array returns a new Float64Array of the given length, filled with zeros.
+func NewFloat64Array(length int) (Float64Array, error) { return NewNumericArray[float64](length) }
+
+// BigInt64ArrayOf converts a JavaScript value into a BigInt64Array, if it is one.
+func BigInt64ArrayOf(v Valuer) (BigInt64Array, bool) { return NumericArrayOf[int64](v) }
+
+// NewBigInt64Array returns a new BigInt64Array of the given length, filled with zeros.
+func NewBigInt64Array(length int) (BigInt64Array, error) { return NewNumericArray[int64](length) }
+
+// BigUint64ArrayOf converts a JavaScript value into a BigUint64Array, if it is one.
+func BigUint64ArrayOf(v Valuer) (BigUint64Array, bool) { return NumericArrayOf[uint64](v) }
+
+// NewBigUint64Array returns a new BigUint64Array of the given length, filled with zeros.
+func NewBigUint64Array(length int) (BigUint64Array, error) { return NewNumericArray[uint64](length) }
@@ -10,7 +10,7 @@ import (
 var Uint8ArrayConstructor = Function{Value: Global.Get("Uint8Array")}
 
 type Uint8Array struct {
-	Object
+	TypedArray
 }
 
 func Uint8ArrayOf(v Valuer) (Uint8Array, bool) {
@@ -24,10 +24,22 @@ func Uint8ArrayOf(v Valuer) (Uint8Array, bool) {
 	}
 
 	return Uint8Array{
-		Object: o,
+		TypedArray: TypedArray{Object: o},
 	}, true
 }
 
+// NewUint8Array returns a new Uint8Array of the given length, filled with zeros.
+func NewUint8Array(length int) (Uint8Array, error) {
+	a, err := newTypedArray(Uint8ArrayConstructor, length)
+	return Uint8Array{TypedArray: a}, err
+}
+
+// Subarray returns a view of the elements of a from begin up to end, which
+// shares its buffer. Negative indices count back from the end.
+func (a Uint8Array) Subarray(begin, end int) Uint8Array {
+	return Uint8Array{TypedArray: a.subarray(begin, end)}
+}
+
 // CopyBytesToGo copies bytes from src to dst.
 // It returns the number of bytes copied, which will be the minimum of the lengths of src and dst.
 func (src Uint8Array) CopyBytesToGo(dst []byte) int {
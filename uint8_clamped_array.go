@@ -2,6 +2,111 @@
 
 package gs
 
-// TODO: create type for Uint8ClampedArray
-// TODO: implement (Uint8ClampedArray).CopyToGo(dst []byte)
-// TODO: implement (Uint8ClampedArray).CopyFromGo(src []byte)
+import "image"
+
+var Uint8ClampedArrayConstructor = Function{Value: Global.Get("Uint8ClampedArray")}
+
+// Uint8ClampedArray is a JavaScript Uint8ClampedArray, as used for the pixels
+// of canvas ImageData.
+type Uint8ClampedArray struct {
+	TypedArray
+}
+
+// Uint8ClampedArrayOf converts a JavaScript value into a Uint8ClampedArray,
+// if it is one.
+func Uint8ClampedArrayOf(v Valuer) (Uint8ClampedArray, bool) {
+	a, ok := typedArrayOf(v, Uint8ClampedArrayConstructor)
+	return Uint8ClampedArray{TypedArray: a}, ok
+}
+
+// NewUint8ClampedArray returns a new Uint8ClampedArray of the given length,
+// filled with zeros.
+func NewUint8ClampedArray(length int) (Uint8ClampedArray, error) {
+	a, err := newTypedArray(Uint8ClampedArrayConstructor, length)
+	return Uint8ClampedArray{TypedArray: a}, err
+}
+
+// Uint8ClampedArrayFromNRGBA returns a new Uint8ClampedArray holding the
+// pixels of img, row by row, in the layout that ImageData expects.
+func Uint8ClampedArrayFromNRGBA(img *image.NRGBA) (Uint8ClampedArray, error) {
+	return clampedFromPix(img.Pix, img.Stride, img.Rect, img.PixOffset, nil)
+}
+
+// Uint8ClampedArrayFromRGBA returns a new Uint8ClampedArray holding the
+// pixels of img, row by row, in the layout that ImageData expects. Since
+// ImageData is not alpha-premultiplied, the colors are converted as they
+// are copied.
+func Uint8ClampedArrayFromRGBA(img *image.RGBA) (Uint8ClampedArray, error) {
+	return clampedFromPix(img.Pix, img.Stride, img.Rect, img.PixOffset, unpremultiply)
+}
+
+// unpremultiply converts a row of alpha-premultiplied RGBA pixels in place,
+// rounding the same way as color.NRGBAModel.
+func unpremultiply(row []byte) {
+	for i := 0; i+3 < len(row); i += 4 {
+		a := uint32(row[i+3]) * 0x101
+		if a == 0 || a == 0xffff {
+			continue
+		}
+
+		for j := 0; j < 3; j++ {
+			c := uint32(row[i+j]) * 0x101
+			row[i+j] = uint8((c * 0xffff / a) >> 8)
+		}
+	}
+}
+
+func clampedFromPix(pix []byte, stride int, r image.Rectangle, offset func(x, y int) int, convert func([]byte)) (Uint8ClampedArray, error) {
+	rowLen := 4 * r.Dx()
+	n := rowLen * r.Dy()
+
+	a, err := NewUint8ClampedArray(n)
+	if err != nil {
+		return Uint8ClampedArray{}, err
+	}
+
+	if n == 0 {
+		return a, nil
+	}
+
+	start := offset(r.Min.X, r.Min.Y)
+
+	var buf []byte
+	if stride == rowLen && convert == nil {
+		// rows are contiguous, so copy straight from the image
+		buf = pix[start : start+n]
+	} else {
+		buf = make([]byte, 0, n)
+		for y := 0; y < r.Dy(); y++ {
+			row := start + y*stride
+			buf = append(buf, pix[row:row+rowLen]...)
+		}
+
+		if convert != nil {
+			convert(buf)
+		}
+	}
+
+	a.CopyFromGo(buf)
+	return a, nil
+}
+
+// Subarray returns a view of the elements of a from begin up to end, which
+// shares its buffer. Negative indices count back from the end.
+func (a Uint8ClampedArray) Subarray(begin, end int) Uint8ClampedArray {
+	return Uint8ClampedArray{TypedArray: a.subarray(begin, end)}
+}
+
+// CopyToGo copies bytes from a to dst, through a Uint8Array view of the same
+// buffer.
+// It returns the number of bytes copied, which will be the minimum of the lengths of a and dst.
+func (a Uint8ClampedArray) CopyToGo(dst []byte) int {
+	return copyToGo(a.TypedArray, dst)
+}
+
+// CopyFromGo copies bytes from src to a, through a Uint8Array view of the
+// same buffer.
+// It returns the number of bytes copied, which will be the minimum of the lengths of src and a.
+func (a Uint8ClampedArray) CopyFromGo(src []byte) int {
+	return copyFromGo(a.TypedArray, src)
+}
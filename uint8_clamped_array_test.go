@@ -0,0 +1,70 @@
+//go:build wasm && js
+
+package gs_test
+
+import (
+	"bytes"
+	"image"
+	"image/color"
+	"testing"
+
+	"github.com/superloach/gs"
+)
+
+func TestUint8ClampedArray(t *testing.T) {
+	a, ok := gs.Uint8ClampedArrayOf(eval(t, "new Uint8ClampedArray([0, 300, -5, 128])"))
+	if !ok {
+		t.Fatal("Uint8ClampedArrayOf rejected a Uint8ClampedArray")
+	}
+
+	dst := make([]byte, 4)
+	if n := a.CopyToGo(dst); n != 4 || !bytes.Equal(dst, []byte{0, 255, 0, 128}) {
+		t.Errorf("CopyToGo: got %v (%d)", dst, n)
+	}
+
+	a.Subarray(2, 4).CopyFromGo([]byte{9, 8})
+	if a.Index(2).Int() != 9 || a.Index(3).Int() != 8 {
+		t.Error("CopyFromGo through subarray")
+	}
+
+	if _, ok := gs.Uint8ArrayOf(a); ok {
+		t.Error("Uint8ArrayOf accepted a Uint8ClampedArray")
+	}
+}
+
+func TestUint8ClampedArrayFromImage(t *testing.T) {
+	img := image.NewNRGBA(image.Rect(0, 0, 4, 4))
+	img.SetNRGBA(1, 1, color.NRGBA{R: 10, G: 20, B: 30, A: 40})
+
+	// a sub-image has a stride wider than its rows
+	sub := img.SubImage(image.Rect(1, 1, 3, 2)).(*image.NRGBA)
+
+	a, err := gs.Uint8ClampedArrayFromNRGBA(sub)
+	if err != nil {
+		t.Fatalf("from NRGBA: %v", err)
+	}
+
+	got := make([]byte, a.Len())
+	a.CopyToGo(got)
+	if !bytes.Equal(got, []byte{10, 20, 30, 40, 0, 0, 0, 0}) {
+		t.Errorf("from NRGBA: got %v", got)
+	}
+
+	rgba := image.NewRGBA(image.Rect(0, 0, 1, 1))
+	rgba.Set(0, 0, color.NRGBA{R: 200, G: 100, B: 0, A: 128})
+
+	a, err = gs.Uint8ClampedArrayFromRGBA(rgba)
+	if err != nil {
+		t.Fatalf("from RGBA: %v", err)
+	}
+
+	// the round trip through premultiplied alpha may lose precision, so
+	// compare against the standard library's conversion
+	want := color.NRGBAModel.Convert(rgba.At(0, 0)).(color.NRGBA)
+
+	got = make([]byte, 4)
+	a.CopyToGo(got)
+	if !bytes.Equal(got, []byte{want.R, want.G, want.B, want.A}) {
+		t.Errorf("from RGBA: got %v", got)
+	}
+}
@@ -0,0 +1,525 @@
+//go:build wasm && js
+
+package gs
+
+import (
+	"math"
+	"math/big"
+	"reflect"
+	"strconv"
+)
+
+// Unmarshaler is implemented by types that can decode a JavaScript value into
+// themselves.
+type Unmarshaler interface {
+	UnmarshalJS(Value) error
+}
+
+// An InvalidUnmarshalError describes an invalid argument passed to Unmarshal.
+// The argument to Unmarshal must be a non-nil pointer.
+type InvalidUnmarshalError struct {
+	Type reflect.Type
+}
+
+func (e *InvalidUnmarshalError) Error() string {
+	if e.Type == nil {
+		return "gs: Unmarshal(nil)"
+	}
+
+	if e.Type.Kind() != reflect.Pointer {
+		return "gs: Unmarshal(non-pointer " + e.Type.String() + ")"
+	}
+
+	return "gs: Unmarshal(nil " + e.Type.String() + ")"
+}
+
+// An UnmarshalTypeError describes a JavaScript value that was not appropriate
+// for a value of a specific Go type.
+type UnmarshalTypeError struct {
+	Value string       // description of the JavaScript value, e.g. "string" or "number 300"
+	Type  reflect.Type // type of Go value it could not be assigned to
+	Path  string       // path of the JavaScript property, e.g. "items[2].name"
+}
+
+func (e *UnmarshalTypeError) Error() string {
+	msg := "gs: cannot unmarshal " + e.Value + " into Go value of type " + e.Type.String()
+	if e.Path != "" {
+		msg += " at " + e.Path
+	}
+
+	return msg
+}
+
+// An UnmarshalerError is returned by Unmarshal when an Unmarshaler returns an
+// error.
+type UnmarshalerError struct {
+	Type reflect.Type
+	Path string
+	Err  error
+}
+
+func (e *UnmarshalerError) Error() string {
+	msg := "gs: error calling UnmarshalJS for type " + e.Type.String()
+	if e.Path != "" {
+		msg += " at " + e.Path
+	}
+
+	return msg + ": " + e.Err.Error()
+}
+
+func (e *UnmarshalerError) Unwrap() error {
+	return e.Err
+}
+
+// Unmarshal decodes the JavaScript value v into the value pointed to by dst.
+// It is the inverse of Marshal:
+//
+//	| JavaScript             | Go                                        |
+//	| ---------------------- | ----------------------------------------- |
+//	| any                    | Unmarshaler, Value                        |
+//	| object                 | Object, struct, map                       |
+//	| function               | Function, Object                          |
+//	| array                  | slice, array                              |
+//	| Uint8Array             | []byte                                    |
+//	| boolean                | bool                                      |
+//	| number                 | integers and floats                       |
+//	| bigint                 | BigInt, big.Int, integers                 |
+//	| string                 | string                                    |
+//	| null, undefined        | nil pointer, interface, slice or map      |
+//
+// Numbers must be integral and in range to decode into an integer type, and
+// in range to decode into a float32. Integral numbers also decode into a
+// big.Int, and bigints decode into integer types when in range. Struct fields are matched to properties
+// using the same names and tags as Marshal. Properties that are missing or
+// undefined leave the corresponding field untouched.
+//
+// Decoding into an empty interface stores bool, float64, *big.Int, string,
+// []any, map[string]any or nil.
+func Unmarshal(v Valuer, dst any) error {
+	rv := reflect.ValueOf(dst)
+	if rv.Kind() != reflect.Pointer || rv.IsNil() {
+		return &InvalidUnmarshalError{Type: reflect.TypeOf(dst)}
+	}
+
+	return unmarshalValue(v.ValueOf(), rv.Elem(), "")
+}
+
+var (
+	unmarshalerType = reflect.TypeOf((*Unmarshaler)(nil)).Elem()
+	valueType       = reflect.TypeOf((*Value)(nil)).Elem()
+	objectType      = reflect.TypeOf((*Object)(nil)).Elem()
+	functionType    = reflect.TypeOf((*Function)(nil)).Elem()
+	gsBigIntType    = reflect.TypeOf((*BigInt)(nil)).Elem()
+)
+
+func unmarshalValue(v Value, rv reflect.Value, path string) error {
+	t := rv.Type()
+
+	if reflect.PointerTo(t).Implements(unmarshalerType) {
+		if err := rv.Addr().Interface().(Unmarshaler).UnmarshalJS(v); err != nil {
+			return &UnmarshalerError{Type: t, Path: path, Err: err}
+		}
+
+		return nil
+	}
+
+	switch t {
+	case valueType:
+		rv.Set(reflect.ValueOf(v))
+		return nil
+	case objectType:
+		o, ok := ObjectOf(v)
+		if !ok {
+			return typeError(v, t, path)
+		}
+
+		rv.Set(reflect.ValueOf(o))
+		return nil
+	case functionType:
+		f, ok := FunctionOf(v)
+		if !ok {
+			return typeError(v, t, path)
+		}
+
+		rv.Set(reflect.ValueOf(f))
+		return nil
+	case gsBigIntType:
+		if v.Type() != TypeBigInt {
+			return typeError(v, t, path)
+		}
+
+		rv.Set(reflect.ValueOf(BigInt{Value: v}))
+		return nil
+	case bigIntType:
+		switch v.Type() {
+		case TypeBigInt:
+			rv.Addr().Interface().(*big.Int).Set(BigInt{Value: v}.Big())
+		case TypeNumber:
+			f := v.Float()
+			if f != math.Trunc(f) || math.IsInf(f, 0) {
+				return numberError(f, t, path)
+			}
+
+			new(big.Float).SetFloat64(f).Int(rv.Addr().Interface().(*big.Int))
+		default:
+			return typeError(v, t, path)
+		}
+
+		return nil
+	}
+
+	vt := v.Type()
+
+	if vt == TypeUndefined || vt == TypeNull {
+		if isNilable(t.Kind()) {
+			rv.SetZero()
+		}
+
+		return nil
+	}
+
+	switch t.Kind() {
+	case reflect.Pointer:
+		if rv.IsNil() {
+			rv.Set(reflect.New(t.Elem()))
+		}
+
+		return unmarshalValue(v, rv.Elem(), path)
+	case reflect.Interface:
+		if t.NumMethod() != 0 {
+			return typeError(v, t, path)
+		}
+
+		x, err := unmarshalAny(v, path)
+		if err != nil {
+			return err
+		}
+
+		rv.Set(reflect.ValueOf(&x).Elem())
+		return nil
+	case reflect.Bool:
+		if vt != TypeBoolean {
+			return typeError(v, t, path)
+		}
+
+		rv.SetBool(Boolean{Value: v}.Bool())
+		return nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		if vt == TypeBigInt {
+			x, ok := BigInt{Value: v}.Int64()
+			if !ok || rv.OverflowInt(x) {
+				return bigIntError(v, t, path)
+			}
+
+			rv.SetInt(x)
+			return nil
+		}
+
+		if vt != TypeNumber {
+			return typeError(v, t, path)
+		}
+
+		f := v.Float()
+		if f != math.Trunc(f) || f < -(1<<63) || f >= 1<<63 || rv.OverflowInt(int64(f)) {
+			return numberError(f, t, path)
+		}
+
+		rv.SetInt(int64(f))
+		return nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		if vt == TypeBigInt {
+			x, ok := BigInt{Value: v}.Uint64()
+			if !ok || rv.OverflowUint(x) {
+				return bigIntError(v, t, path)
+			}
+
+			rv.SetUint(x)
+			return nil
+		}
+
+		if vt != TypeNumber {
+			return typeError(v, t, path)
+		}
+
+		f := v.Float()
+		if f != math.Trunc(f) || f < 0 || f >= 1<<64 || rv.OverflowUint(uint64(f)) {
+			return numberError(f, t, path)
+		}
+
+		rv.SetUint(uint64(f))
+		return nil
+	case reflect.Float32, reflect.Float64:
+		if vt != TypeNumber {
+			return typeError(v, t, path)
+		}
+
+		f := v.Float()
+		if !math.IsInf(f, 0) && rv.OverflowFloat(f) {
+			return numberError(f, t, path)
+		}
+
+		rv.SetFloat(f)
+		return nil
+	case reflect.String:
+		if vt != TypeString {
+			return typeError(v, t, path)
+		}
+
+		rv.SetString(v.String())
+		return nil
+	case reflect.Slice:
+		if t.Elem().Kind() == reflect.Uint8 {
+			if a, ok := Uint8ArrayOf(v); ok {
+				b := make([]byte, a.Length())
+				a.CopyBytesToGo(b)
+				rv.SetBytes(b)
+				return nil
+			}
+		}
+
+		if !isArray(v) {
+			return typeError(v, t, path)
+		}
+
+		n := v.Length()
+		if rv.Cap() < n {
+			rv.Set(reflect.MakeSlice(t, n, n))
+		}
+		rv.SetLen(n)
+
+		return unmarshalElems(v, rv, n, path)
+	case reflect.Array:
+		if !isArray(v) {
+			return typeError(v, t, path)
+		}
+
+		n := v.Length()
+		if n > rv.Len() {
+			n = rv.Len()
+		}
+		for i := n; i < rv.Len(); i++ {
+			rv.Index(i).SetZero()
+		}
+
+		return unmarshalElems(v, rv, n, path)
+	case reflect.Map:
+		o, ok := ObjectOf(v)
+		if !ok {
+			return typeError(v, t, path)
+		}
+
+		return unmarshalMap(o, rv, path)
+	case reflect.Struct:
+		o, ok := ObjectOf(v)
+		if !ok {
+			return typeError(v, t, path)
+		}
+
+		return unmarshalStruct(o, rv, path)
+	default:
+		return typeError(v, t, path)
+	}
+}
+
+func typeError(v Value, t reflect.Type, path string) error {
+	return &UnmarshalTypeError{Value: v.Type().String(), Type: t, Path: path}
+}
+
+func numberError(f float64, t reflect.Type, path string) error {
+	return &UnmarshalTypeError{
+		Value: "number " + strconv.FormatFloat(f, 'g', -1, 64),
+		Type:  t,
+		Path:  path,
+	}
+}
+
+func bigIntError(v Value, t reflect.Type, path string) error {
+	return &UnmarshalTypeError{
+		Value: "bigint " + jsString(v),
+		Type:  t,
+		Path:  path,
+	}
+}
+
+func indexPath(path string, i int) string {
+	return path + "[" + strconv.Itoa(i) + "]"
+}
+
+func propertyPath(path, name string) string {
+	if path == "" {
+		return name
+	}
+
+	return path + "." + name
+}
+
+func isArray(v Value) bool {
+	if !v.Type().IsObject() {
+		return false
+	}
+
+	res, err := Object{Value: ArrayConstructor.Value}.Call("isArray", v)
+	if err != nil {
+		return false
+	}
+
+	return res.Truthy()
+}
+
+func objectKeys(o Object) ([]string, error) {
+	res, err := Object{Value: ObjectConstructor.Value}.Call("keys", o)
+	if err != nil {
+		return nil, err
+	}
+
+	keys := make([]string, res.Length())
+	for i := range keys {
+		keys[i] = res.Index(i).String()
+	}
+
+	return keys, nil
+}
+
+func unmarshalElems(v Value, rv reflect.Value, n int, path string) error {
+	for i := 0; i < n; i++ {
+		if err := unmarshalValue(v.Index(i), rv.Index(i), indexPath(path, i)); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func unmarshalMap(o Object, rv reflect.Value, path string) error {
+	t := rv.Type()
+
+	switch t.Key().Kind() {
+	case reflect.String,
+		reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+	default:
+		return typeError(o.Value, t, path)
+	}
+
+	keys, err := objectKeys(o)
+	if err != nil {
+		return err
+	}
+
+	if rv.IsNil() {
+		rv.Set(reflect.MakeMapWithSize(t, len(keys)))
+	}
+
+	for _, k := range keys {
+		kp := propertyPath(path, k)
+
+		kv := reflect.New(t.Key()).Elem()
+		switch t.Key().Kind() {
+		case reflect.String:
+			kv.SetString(k)
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			n, err := strconv.ParseInt(k, 10, 64)
+			if err != nil || kv.OverflowInt(n) {
+				return &UnmarshalTypeError{Value: "number " + k, Type: t.Key(), Path: kp}
+			}
+			kv.SetInt(n)
+		default:
+			n, err := strconv.ParseUint(k, 10, 64)
+			if err != nil || kv.OverflowUint(n) {
+				return &UnmarshalTypeError{Value: "number " + k, Type: t.Key(), Path: kp}
+			}
+			kv.SetUint(n)
+		}
+
+		ev := reflect.New(t.Elem()).Elem()
+		if err := unmarshalValue(o.Get(k), ev, kp); err != nil {
+			return err
+		}
+
+		rv.SetMapIndex(kv, ev)
+	}
+
+	return nil
+}
+
+func unmarshalStruct(o Object, rv reflect.Value, path string) error {
+	for _, f := range cachedFields(rv.Type()) {
+		p := o.Get(f.name)
+		if p.IsUndefined() {
+			continue
+		}
+
+		fv := rv
+		for _, i := range f.index {
+			if fv.Kind() == reflect.Pointer {
+				if fv.IsNil() {
+					if !fv.CanSet() {
+						return &UnmarshalTypeError{
+							Value: p.Type().String(),
+							Type:  fv.Type(),
+							Path:  propertyPath(path, f.name),
+						}
+					}
+					fv.Set(reflect.New(fv.Type().Elem()))
+				}
+				fv = fv.Elem()
+			}
+			fv = fv.Field(i)
+		}
+
+		if err := unmarshalValue(p, fv, propertyPath(path, f.name)); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// unmarshalAny decodes v into the Go value that best represents it.
+func unmarshalAny(v Value, path string) (any, error) {
+	switch v.Type() {
+	case TypeUndefined, TypeNull:
+		return nil, nil
+	case TypeBoolean:
+		return Boolean{Value: v}.Bool(), nil
+	case TypeNumber:
+		return v.Float(), nil
+	case TypeString:
+		return v.String(), nil
+	case TypeBigInt:
+		return BigInt{Value: v}.Big(), nil
+	case TypeObject:
+		if isArray(v) {
+			s := make([]any, v.Length())
+			for i := range s {
+				e, err := unmarshalAny(v.Index(i), indexPath(path, i))
+				if err != nil {
+					return nil, err
+				}
+				s[i] = e
+			}
+
+			return s, nil
+		}
+
+		o := Object{Value: v}
+
+		keys, err := objectKeys(o)
+		if err != nil {
+			return nil, err
+		}
+
+		m := make(map[string]any, len(keys))
+		for _, k := range keys {
+			e, err := unmarshalAny(o.Get(k), propertyPath(path, k))
+			if err != nil {
+				return nil, err
+			}
+			m[k] = e
+		}
+
+		return m, nil
+	default:
+		return v, nil
+	}
+}
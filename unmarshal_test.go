@@ -0,0 +1,108 @@
+//go:build wasm && js
+
+package gs_test
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/superloach/gs"
+)
+
+func eval(t *testing.T, src string) gs.Value {
+	t.Helper()
+
+	v, err := gs.Global.Call("eval", gs.ToString(src))
+	if err != nil {
+		t.Fatalf("eval %q: %v", src, err)
+	}
+
+	return v
+}
+
+type unmarshalItem struct {
+	Name  string  `js:"name"`
+	Count uint8   `js:"count"`
+	Score float32 `js:"score"`
+}
+
+type unmarshalList struct {
+	Items []unmarshalItem `js:"items"`
+	Meta  map[string]any  `js:"meta"`
+	Ptr   *int            `js:"ptr"`
+	Attrs map[int]string  `js:"attrs"`
+	Raw   gs.Value        `js:"raw"`
+}
+
+func TestUnmarshal(t *testing.T) {
+	v := eval(t, `({
+		items: [{name: "a", count: 1, score: 0.5}, {name: "b", count: 255}],
+		meta: {ok: true, list: [1, "x", null]},
+		ptr: 42,
+		attrs: {"1": "one"},
+		raw: "keep",
+	})`)
+
+	var got unmarshalList
+	if err := gs.Unmarshal(v, &got); err != nil {
+		t.Fatalf("unmarshal: %v", err)
+	}
+
+	want := []unmarshalItem{{"a", 1, 0.5}, {"b", 255, 0}}
+	if !reflect.DeepEqual(got.Items, want) {
+		t.Errorf("items: got %+v, want %+v", got.Items, want)
+	}
+
+	wantMeta := map[string]any{"ok": true, "list": []any{1.0, "x", nil}}
+	if !reflect.DeepEqual(got.Meta, wantMeta) {
+		t.Errorf("meta: got %#v, want %#v", got.Meta, wantMeta)
+	}
+
+	if got.Ptr == nil || *got.Ptr != 42 {
+		t.Errorf("ptr: got %v", got.Ptr)
+	}
+
+	if got.Attrs[1] != "one" {
+		t.Errorf("attrs: got %v", got.Attrs)
+	}
+
+	if got.Raw.String() != "keep" {
+		t.Errorf("raw: got %v", got.Raw)
+	}
+}
+
+func TestUnmarshalTypeError(t *testing.T) {
+	for _, tt := range []struct {
+		src   string
+		value string
+		path  string
+	}{
+		{`({items: [{}, {count: 256}]})`, "number 256", "items[1].count"},
+		{`({items: [{count: 1.5}]})`, "number 1.5", "items[0].count"},
+		{`({items: [{name: 3}]})`, "number", "items[0].name"},
+		{`({items: "nope"})`, "string", "items"},
+	} {
+		var dst unmarshalList
+		err := gs.Unmarshal(eval(t, tt.src), &dst)
+
+		var ute *gs.UnmarshalTypeError
+		if !errors.As(err, &ute) {
+			t.Errorf("%s: expected UnmarshalTypeError, got %v", tt.src, err)
+			continue
+		}
+
+		if ute.Value != tt.value || ute.Path != tt.path {
+			t.Errorf("%s: got %q at %q, want %q at %q", tt.src, ute.Value, ute.Path, tt.value, tt.path)
+		}
+	}
+}
+
+func TestUnmarshalInvalid(t *testing.T) {
+	var dst int
+
+	var iue *gs.InvalidUnmarshalError
+	if err := gs.Unmarshal(gs.ValueOf(1), dst); !errors.As(err, &iue) {
+		t.Errorf("expected InvalidUnmarshalError, got %v", err)
+	}
+}
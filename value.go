@@ -12,6 +12,7 @@
 package gs
 
 import (
+	"math/big"
 	"runtime"
 	"strconv"
 	"unsafe"
@@ -53,10 +54,15 @@ const (
 	TypeFlagFunction
 )
 
+// maxPredefNone is the highest ID of the predefined values without a type
+// flag (NaN, 0, null, true and false). Any other ID without a type flag
+// refers to a bigint, which wasm_exec.js stores like any other reference.
+const maxPredefNone = 4
+
 func MakeValue(r Ref) Value {
 	var gcPtr *Ref
 	typeFlag := (r >> 32) & 7
-	if (r>>32)&NaNHead == NaNHead && typeFlag != TypeFlagNone {
+	if (r>>32)&NaNHead == NaNHead && (typeFlag != TypeFlagNone || uint32(r) > maxPredefNone) {
 		gcPtr = new(Ref)
 		*gcPtr = r
 		runtime.SetFinalizer(gcPtr, func(p *Ref) {
@@ -89,23 +95,15 @@ func FloatValue(f float64) Value {
 	return Value{Ref: *(*Ref)(unsafe.Pointer(&f))}
 }
 
-// Error wraps a JavaScript error.
-type Error struct {
-	// Object is the underlying JavaScript error object.
-	Object
-}
-
-// Error implements the error interface.
-func (e Error) Error() string {
-	return "JavaScript error: " + e.Get("message").String()
-}
-
 var (
 	ValueNaN  = PredefValue(0, TypeFlagNone)
 	ValueZero = PredefValue(1, TypeFlagNone)
 )
 
 // Equal reports whether v and w are equal according to JavaScript's === operator.
+//
+// This holds for bigints too, since wasm_exec.js hands out a single reference
+// for all live bigints with the same value.
 func (v Value) Equal(w Value) bool {
 	return v.Ref == w.Ref && v.Ref != ValueNaN.Ref
 }
@@ -134,11 +132,13 @@ func (v Value) IsNaN() bool {
 //	| nil                    | null                   |
 //	| bool                   | boolean                |
 //	| integers and floats    | number                 |
+//	| *big.Int               | bigint                 |
 //	| string                 | string                 |
 //	| []interface{}          | new array              |
 //	| map[string]interface{} | new object             |
 //
-// Panics if x is not one of the expected types.
+// Any other x is converted with Marshal.
+// Panics if x cannot be marshaled.
 func ValueOf(x any) Value {
 	switch x := x.(type) {
 	case Value:
@@ -181,20 +181,26 @@ func ValueOf(x any) Value {
 		return FloatValue(float64(x))
 	case float64:
 		return FloatValue(x)
+	case *big.Int:
+		if x == nil {
+			return Null.Value
+		}
+
+		return NewBigInt(x).Value
 	case string:
 		return MakeValue(stringVal(x))
 	case []any:
-		l := FloatValue(float64(len(x)))
-		a, err := ArrayConstructor.New(l)
-		if err != nil {
-			panic("array construction error: " + err.Error())
+		elems := make([]Valuer, len(x))
+		for i, e := range x {
+			elems[i] = ValueOf(e)
 		}
 
-		for i, s := range x {
-			a.SetIndex(i, s)
+		a, err := NewArray(elems...)
+		if err != nil {
+			panic("array construction error: " + err.Error())
 		}
 
-		return a
+		return a.Value
 	case map[string]any:
 		o, err := ObjectConstructor.New()
 		if err != nil {
@@ -209,7 +215,12 @@ func ValueOf(x any) Value {
 	case Valuer:
 		return x.ValueOf()
 	default:
-		panic("ValueOf: invalid value")
+		v, err := Marshal(x)
+		if err != nil {
+			panic("ValueOf: " + err.Error())
+		}
+
+		return v
 	}
 }
 
@@ -257,8 +268,6 @@ func (t Type) IsObject() bool {
 	return t == TypeObject || t == TypeFunction
 }
 
-var constructorProperty = ToString("constructor")
-
 // Type returns the JavaScript type of the value v. It is similar to JavaScript's typeof operator,
 // except that it returns TypeNull instead of TypeObject for null.
 func (v Value) Type() Type {
@@ -283,16 +292,10 @@ func (v Value) Type() Type {
 		return TypeSymbol
 	case TypeFlagFunction:
 		return TypeFunction
+	case TypeFlagNone:
+		// everything else without a flag was handled above
+		return TypeBigInt
 	default:
-		constructor := Reflect.Get(
-			Reflect.Constructor(v),
-			constructorProperty,
-		)
-
-		if constructor.Equal(Global.Get("BigInt")) {
-			return TypeBigInt
-		}
-
 		panic("invalid type: " + strconv.Itoa(int(typeFlag)))
 	}
 }
@@ -420,6 +423,8 @@ func (v Value) Truthy() bool {
 		return v.Ref != ValueNaN.Ref && v.Ref != ValueZero.Ref
 	case TypeString:
 		return v.String() != ""
+	case TypeBigInt:
+		return !v.Equal(bigIntZero.Value)
 	case TypeSymbol, TypeFunction, TypeObject:
 		return true
 	default:
@@ -442,7 +447,9 @@ func (v Value) String() string {
 	case TypeBoolean:
 		return "<boolean: " + jsString(v) + ">"
 	case TypeNumber:
-		return "<number: " + jsString(v) + ">"
+		return "<number: " + formatNumber(v.Float(), 10) + ">"
+	case TypeBigInt:
+		return "<bigint: " + jsString(v) + ">"
 	case TypeSymbol:
 		return "<symbol>"
 	case TypeObject: